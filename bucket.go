@@ -2,13 +2,12 @@ package oss
 
 import (
 	"fmt"
+	"html"
 	"io"
 	"net/http"
 	"net/url"
-	"os"
 	"strings"
 
-	"github.com/joho/godotenv"
 	"github.com/tu6ge/oss-go/types"
 )
 
@@ -17,6 +16,8 @@ type Bucket struct {
 	endpoint types.EndPoint
 	query    types.ObjectQuery
 	domain   string
+	// 列出 bucket 时返回的基本信息
+	info *BucketInfo
 }
 
 func NewBucket(name, endpoint string) (Bucket, error) {
@@ -25,25 +26,48 @@ func NewBucket(name, endpoint string) (Bucket, error) {
 		return Bucket{}, err
 	}
 
-	if len(name) == 0 {
+	if !is_valid_bucket_name(name) {
 		return Bucket{}, &InvalidBucketName{}
 	}
 
-	return Bucket{name, end, types.NewObjectQuery(), ""}, nil
+	return Bucket{name, end, types.NewObjectQuery(), "", nil}, nil
 }
 
-func BucketFromEnv() (Bucket, error) {
-	err := godotenv.Load()
+// is_valid_bucket_name bucket 名称长度为 3~63，只能包含小写字母、数字和短横线，
+// 且不能以短横线开头或结尾
+func is_valid_bucket_name(name string) bool {
+	if len(name) < 3 || len(name) > 63 {
+		return false
+	}
+	if strings.HasPrefix(name, "-") || strings.HasSuffix(name, "-") {
+		return false
+	}
+	for _, char := range name {
+		if !(char >= 'a' && char <= 'z' || char >= '0' && char <= '9' || char == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+func BucketFromEnv(files ...string) (Bucket, error) {
+	err := types.LoadEnv(files...)
 	if err != nil {
 		return Bucket{}, err
 	}
-	name := os.Getenv("ALIYUN_BUCKET")
-	end, err := types.EndPointFromEnv()
+	name, err := types.LookupEnv(types.ENV_BUCKET...)
+	if err != nil {
+		return Bucket{}, err
+	}
+	if !is_valid_bucket_name(name) {
+		return Bucket{}, &InvalidBucketName{}
+	}
+	end, err := types.EndPointFromEnv(files...)
 	if err != nil {
 		return Bucket{}, err
 	}
 
-	return Bucket{name, end, types.NewObjectQuery(), ""}, err
+	return Bucket{name, end, types.NewObjectQuery(), "", nil}, err
 }
 
 func (b *Bucket) SetEndPointDomain(domain string) error {
@@ -64,6 +88,15 @@ func (b *Bucket) ToUrl() url.URL {
 	return *u
 }
 
+// ListInfo 返回 GetBuckets 列出 bucket 时得到的地域、创建时间、存储类型等信息，
+// 不是通过列表得到的 bucket 返回 false
+func (b Bucket) ListInfo() (BucketInfo, bool) {
+	if b.info == nil {
+		return BucketInfo{}, false
+	}
+	return *b.info, true
+}
+
 func (b Bucket) Query(query map[string]string) Bucket {
 	for key, val := range query {
 		b.query.Insert(key, val)
@@ -116,11 +149,14 @@ func (b Bucket) GetObjects(client *Client) (Objects, error) {
 	if http_status_ok(resp.StatusCode) {
 		token := parse_item(body_string, "NextContinuationToken")
 		object_rs := parser_xml_objects(body_string)
+		for i := range object_rs {
+			object_rs[i].bucket = &b
+		}
 
-		return Objects{object_rs, token, b.query}, nil
+		return Objects{object_rs, token, b.query, b}, nil
 	} else {
 		// fmt.Println(body_string)
-		return Objects{}, parse_oss_response_error(body_string)
+		return Objects{}, client.response_error(resp, body_string)
 	}
 }
 
@@ -164,7 +200,7 @@ func parser_xml_objects(xml string) []Object {
 
 	var buckets []Object
 	for i, item := range start_positions {
-		name := xml[item+len("<Key>") : end_positions[i]]
+		name := html.UnescapeString(xml[item+len("<Key>") : end_positions[i]])
 
 		buckets = append(buckets, NewObject(name))
 	}
@@ -0,0 +1,102 @@
+package oss
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+type CreateBucketOptions struct {
+	// bucket 所在的地域，为空时使用 client.Bucket 的地域
+	EndPoint           *types.EndPoint
+	StorageClass       types.StorageClass
+	DataRedundancyType types.DataRedundancyType
+	ACL                types.ACL
+	ResourceGroupId    string
+}
+
+type create_bucket_configuration struct {
+	XMLName            xml.Name                 `xml:"CreateBucketConfiguration"`
+	StorageClass       types.StorageClass       `xml:",omitempty"`
+	DataRedundancyType types.DataRedundancyType `xml:",omitempty"`
+}
+
+// CreateBucket 创建 bucket，返回新 bucket 的句柄。bucket 已存在时返回 ErrBucketAlreadyExists
+func (c Client) CreateBucket(name string, opts CreateBucketOptions) (Bucket, error) {
+	end := c.Bucket.endpoint
+	if opts.EndPoint != nil {
+		end = *opts.EndPoint
+	}
+	if !is_valid_bucket_name(name) {
+		return Bucket{}, &InvalidBucketName{}
+	}
+	bucket := Bucket{name, end, types.NewObjectQuery(), "", nil}
+
+	body, err := xml.Marshal(create_bucket_configuration{
+		StorageClass:       opts.StorageClass,
+		DataRedundancyType: opts.DataRedundancyType,
+	})
+	if err != nil {
+		return Bucket{}, err
+	}
+
+	headers := map[string]string{
+		"Content-Length": strconv.Itoa(len(body)),
+	}
+	if len(opts.ACL) > 0 {
+		headers["x-oss-acl"] = string(opts.ACL)
+	}
+	if len(opts.ResourceGroupId) > 0 {
+		headers["x-oss-resource-group-id"] = opts.ResourceGroupId
+	}
+
+	err = c.bucket_request(bucket, "PUT", headers, body)
+	if err != nil {
+		return Bucket{}, err
+	}
+	return bucket, nil
+}
+
+// DeleteBucket 删除 bucket，bucket 中还有文件或未完成的分片上传时返回 ErrBucketNotEmpty
+func (c Client) DeleteBucket(bucket Bucket) error {
+	return c.bucket_request(bucket, "DELETE", make(map[string]string), nil)
+}
+
+func (c Client) bucket_request(bucket Bucket, method string, headers map[string]string, body []byte) error {
+	url := bucket.ToUrl()
+
+	resource := types.NewCanonicalizedResource(fmt.Sprintf("/%s/", bucket.name))
+	headers = c.AuthorizationHeader(method, resource, headers)
+
+	req, err := http.NewRequest(method, url.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	for k, v := range headers {
+		req.Header.Add(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if http_status_ok(resp.StatusCode) {
+		return nil
+	} else {
+		// 读取响应体
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return c.response_error(resp, string(data))
+	}
+}
@@ -0,0 +1,35 @@
+package oss
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+func TestBucketName(t *testing.T) {
+	valid := []string{"abc", "honglei123", "my-bucket-01", strings.Repeat("a", 63)}
+	invalid := []string{"", "ab", "-abc", "abc-", "ABC", "my_bucket", "my.bucket", strings.Repeat("a", 64)}
+
+	for _, name := range valid {
+		if _, err := NewBucket(name, "cn-shanghai"); err != nil {
+			t.Error("bucket name should be valid:", name)
+		}
+	}
+	for _, name := range invalid {
+		if _, err := NewBucket(name, "cn-shanghai"); err == nil {
+			t.Errorf("bucket name should be invalid: %q", name)
+		}
+	}
+}
+
+func TestCreateBucketConfiguration(t *testing.T) {
+	data, _ := xml.Marshal(create_bucket_configuration{
+		StorageClass:       types.STORAGE_CLASS_IA,
+		DataRedundancyType: types.DATA_REDUNDANCY_ZRS,
+	})
+	if string(data) != "<CreateBucketConfiguration><StorageClass>IA</StorageClass><DataRedundancyType>ZRS</DataRedundancyType></CreateBucketConfiguration>" {
+		t.Error("create bucket xml error:", string(data))
+	}
+}
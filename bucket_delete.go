@@ -0,0 +1,311 @@
+package oss
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+// 每次批量删除最多 1000 个文件
+const DELETE_BATCH_SIZE = 1000
+
+// DeleteKey 批量删除中的一个文件，VersionId 为空时删除当前版本
+type DeleteKey struct {
+	Key       string
+	VersionId string
+}
+
+// DeleteKeys 把文件名转换为 DeleteKey
+func DeleteKeys(keys ...string) []DeleteKey {
+	list := make([]DeleteKey, len(keys))
+	for i, key := range keys {
+		list[i] = DeleteKey{Key: key}
+	}
+	return list
+}
+
+type DeletedObject struct {
+	Key                   string
+	VersionId             string
+	DeleteMarker          bool
+	DeleteMarkerVersionId string
+}
+
+type DeleteObjectError struct {
+	Key       string
+	VersionId string
+	Err       error
+}
+
+func (e DeleteObjectError) Error() string {
+	return fmt.Sprintf("delete %s: %s", e.Key, e.Err)
+}
+
+func (e DeleteObjectError) Unwrap() error {
+	return e.Err
+}
+
+// DeleteObjectsResult 批量删除的结果，quiet 模式下 Deleted 为空
+type DeleteObjectsResult struct {
+	Deleted []DeletedObject
+	Errors  []DeleteObjectError
+}
+
+// DeleteObjects 批量删除文件，超过 1000 个时分批删除。
+// 某一批请求失败时，这一批的文件都会记录在 Errors 中，并返回合并后的 error
+func (b Bucket) DeleteObjects(client *Client, keys []DeleteKey, quiet bool) (DeleteObjectsResult, error) {
+	var result DeleteObjectsResult
+	var errs []error
+
+	for start := 0; start < len(keys); start += DELETE_BATCH_SIZE {
+		batch := keys[start:min(start+DELETE_BATCH_SIZE, len(keys))]
+
+		res, err := b.delete_objects(client, batch, quiet)
+		if err != nil {
+			errs = append(errs, err)
+			for _, key := range batch {
+				result.Errors = append(result.Errors, DeleteObjectError{key.Key, key.VersionId, err})
+			}
+			continue
+		}
+		result.Deleted = append(result.Deleted, res.Deleted...)
+		result.Errors = append(result.Errors, res.Errors...)
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func (b Bucket) delete_objects(client *Client, keys []DeleteKey, quiet bool) (DeleteObjectsResult, error) {
+	url := b.ToUrl()
+	url.RawQuery = "delete"
+	method := "POST"
+
+	resource := types.NewCanonicalizedResource(fmt.Sprintf("/%s/?delete", b.name))
+
+	body := delete_objects_xml(keys, quiet)
+
+	headers := map[string]string{
+		"Content-Length": strconv.Itoa(len(body)),
+		"Content-MD5":    content_md5(body),
+		"Content-Type":   "application/xml",
+	}
+	headers = client.AuthorizationHeader(method, resource, headers)
+
+	req, err := http.NewRequest(method, url.String(), bytes.NewReader(body))
+	if err != nil {
+		return DeleteObjectsResult{}, err
+	}
+
+	for k, v := range headers {
+		req.Header.Add(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return DeleteObjectsResult{}, err
+	}
+
+	defer resp.Body.Close()
+
+	// 读取响应体
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return DeleteObjectsResult{}, err
+	}
+
+	if !http_status_ok(resp.StatusCode) {
+		return DeleteObjectsResult{}, client.response_error(resp, string(data))
+	}
+
+	return parse_delete_objects_result(data)
+}
+
+func delete_objects_xml(keys []DeleteKey, quiet bool) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?><Delete>`)
+	fmt.Fprintf(&buf, "<Quiet>%t</Quiet>", quiet)
+	for _, key := range keys {
+		buf.WriteString("<Object><Key>")
+		xml.EscapeText(&buf, []byte(key.Key))
+		buf.WriteString("</Key>")
+		if len(key.VersionId) > 0 {
+			buf.WriteString("<VersionId>")
+			xml.EscapeText(&buf, []byte(key.VersionId))
+			buf.WriteString("</VersionId>")
+		}
+		buf.WriteString("</Object>")
+	}
+	buf.WriteString("</Delete>")
+	return buf.Bytes()
+}
+
+func parse_delete_objects_result(data []byte) (DeleteObjectsResult, error) {
+	var body struct {
+		Deleted []DeletedObject `xml:"Deleted"`
+		Errors  []struct {
+			Key       string
+			VersionId string
+			Code      string
+			Message   string
+		} `xml:"Error"`
+	}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := xml.Unmarshal(data, &body); err != nil {
+			return DeleteObjectsResult{}, err
+		}
+	}
+
+	result := DeleteObjectsResult{Deleted: body.Deleted}
+	for _, item := range body.Errors {
+		err := &OssResponseError{Code: item.Code, Message: item.Message}
+		result.Errors = append(result.Errors, DeleteObjectError{item.Key, item.VersionId, err})
+	}
+	return result, nil
+}
+
+type DeletePrefixOptions struct {
+	// 只列出将要删除的文件，不真正删除
+	DryRun bool
+	// 同时进行的批量删除请求数量，默认为 3
+	Parallel int
+	// 同时取消 prefix 下进行中的分片上传
+	AbortUploads bool
+}
+
+// DeletePrefixResult 按前缀删除的结果，DryRun 时 Deleted 为将要删除的文件
+type DeletePrefixResult struct {
+	Deleted        []string
+	Errors         []DeleteObjectError
+	AbortedUploads []MultipartUpload
+}
+
+// DeletePrefix 删除 prefix 下的所有文件，一边分页列出文件一边并发批量删除。
+// 列出文件失败或 ctx 取消时停止并返回 error，已经删除的文件记录在结果中，
+// ctx 取消时正在进行的批量删除请求会继续完成
+func (b Bucket) DeletePrefix(ctx context.Context, client *Client, prefix string, opts DeletePrefixOptions) (DeletePrefixResult, error) {
+	if len(prefix) == 0 {
+		return DeletePrefixResult{}, errors.New("prefix can not be empty")
+	}
+	parallel := opts.Parallel
+	if parallel < 1 {
+		parallel = 3
+	}
+
+	var result DeletePrefixResult
+	var mu sync.Mutex
+
+	batches := make(chan []DeleteKey)
+	var wg sync.WaitGroup
+	for range parallel {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				// ctx 取消后不再处理新的批次
+				if ctx.Err() != nil {
+					continue
+				}
+				res, err := b.delete_objects(client, batch, true)
+
+				mu.Lock()
+				result.Errors = append(result.Errors, res.Errors...)
+				failed := make(map[string]bool)
+				for _, item := range res.Errors {
+					failed[item.Key] = true
+				}
+				for _, key := range batch {
+					if err != nil {
+						result.Errors = append(result.Errors, DeleteObjectError{key.Key, key.VersionId, err})
+					} else if !failed[key.Key] {
+						result.Deleted = append(result.Deleted, key.Key)
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	err := b.list_prefix(ctx, client, prefix, func(keys []DeleteKey) error {
+		if opts.DryRun {
+			for _, key := range keys {
+				result.Deleted = append(result.Deleted, key.Key)
+			}
+			return nil
+		}
+		select {
+		case batches <- keys:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	close(batches)
+	wg.Wait()
+	if err == nil {
+		// 列出完成后 ctx 才取消时，可能有批次被跳过
+		err = ctx.Err()
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if opts.AbortUploads {
+		uploads, err := b.ListMultipartUploads(client, prefix)
+		if err != nil {
+			return result, err
+		}
+		if opts.DryRun {
+			result.AbortedUploads = uploads
+			return result, nil
+		}
+		for _, upload := range uploads {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			if err := upload.Abort(client); err != nil {
+				return result, err
+			}
+			result.AbortedUploads = append(result.AbortedUploads, upload)
+		}
+	}
+
+	return result, nil
+}
+
+// list_prefix 分页列出 prefix 下的所有文件，每一页调用一次 fn，fn 返回 error 时停止
+func (b Bucket) list_prefix(ctx context.Context, client *Client, prefix string, fn func([]DeleteKey) error) error {
+	query := types.NewObjectQuery()
+	query.Insert(types.QUERY_PREFIX, prefix)
+	query.Insert(types.QUERY_MAX_KEYS, strconv.Itoa(DELETE_BATCH_SIZE))
+
+	objects, err := b.ObjectQuery(query).GetObjects(client)
+	for err == nil {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		keys := make([]DeleteKey, len(objects.List))
+		for i, obj := range objects.List {
+			keys[i] = DeleteKey{Key: obj.path}
+		}
+		if len(keys) > 0 {
+			if err := fn(keys); err != nil {
+				return err
+			}
+		}
+
+		if len(objects.NextToken) == 0 {
+			return nil
+		}
+		objects, err = objects.NextList(client)
+	}
+	return err
+}
@@ -0,0 +1,159 @@
+package oss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDeleteObjectsXml(t *testing.T) {
+	keys := append(DeleteKeys("a.txt", "b&c.txt"), DeleteKey{"d.txt", "CAEQ"})
+	xml := string(delete_objects_xml(keys, true))
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?><Delete><Quiet>true</Quiet>` +
+		`<Object><Key>a.txt</Key></Object>` +
+		`<Object><Key>b&amp;c.txt</Key></Object>` +
+		`<Object><Key>d.txt</Key><VersionId>CAEQ</VersionId></Object></Delete>`
+	if xml != expected {
+		t.Error("delete objects xml error:", xml)
+	}
+}
+
+func TestParseDeleteObjectsResult(t *testing.T) {
+	xml := `<?xml version="1.0" encoding="UTF-8"?>
+<DeleteResult>
+  <Deleted>
+    <Key>a.txt</Key>
+  </Deleted>
+  <Deleted>
+    <Key>d.txt</Key>
+    <DeleteMarker>true</DeleteMarker>
+    <DeleteMarkerVersionId>CAEQMhiBgIDXiaaB0BYiIGQzYmRkZGUxMTM1ZDRjOTZhNjk4YjRjMTAyZjhl****</DeleteMarkerVersionId>
+  </Deleted>
+  <Error>
+    <Key>b.txt</Key>
+    <Code>AccessDenied</Code>
+    <Message>Access Denied</Message>
+  </Error>
+</DeleteResult>`
+
+	result, err := parse_delete_objects_result([]byte(xml))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Deleted) != 2 || result.Deleted[0].Key != "a.txt" || !result.Deleted[1].DeleteMarker {
+		t.Error("parse deleted objects error")
+	}
+	if len(result.Errors) != 1 || !errors.Is(result.Errors[0], ErrAccessDenied) {
+		t.Error("parse delete errors error")
+	}
+}
+
+func TestDeletePrefix(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	var aborted []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.Method == "GET" && query.Get("list-type") == "2":
+			if query.Get("continuation-token") == "" {
+				fmt.Fprint(w, "<ListBucketResult><Contents><Key>logs/a.log</Key></Contents><Contents><Key>logs/b&amp;c.log</Key></Contents><NextContinuationToken>next</NextContinuationToken></ListBucketResult>")
+			} else {
+				fmt.Fprint(w, "<ListBucketResult><Contents><Key>logs/d.log</Key></Contents></ListBucketResult>")
+			}
+		case r.Method == "POST" && query.Has("delete"):
+			body, _ := io.ReadAll(r.Body)
+			if r.Header.Get("Content-MD5") != content_md5(body) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			mu.Lock()
+			deleted = append(deleted, string(body))
+			mu.Unlock()
+		case r.Method == "GET" && query.Has("uploads"):
+			fmt.Fprint(w, "<ListMultipartUploadsResult><IsTruncated>false</IsTruncated><Upload><Key>logs/big.log</Key><UploadId>abc</UploadId></Upload></ListMultipartUploadsResult>")
+		case r.Method == "DELETE" && query.Get("uploadId") == "abc":
+			aborted = append(aborted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := New("foo", "bar", "honglei123", "cn-shanghai")
+	client.SetBucketDomain(server.URL)
+
+	result, err := client.Bucket.DeletePrefix(context.Background(), &client, "logs/", DeletePrefixOptions{DryRun: true, AbortUploads: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Deleted) != 3 || result.Deleted[1] != "logs/b&c.log" || len(result.AbortedUploads) != 1 || len(deleted) != 0 {
+		t.Error("dry run delete prefix error:", result)
+	}
+
+	result, err = client.Bucket.DeletePrefix(context.Background(), &client, "logs/", DeletePrefixOptions{AbortUploads: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(result.Deleted)
+	if !slices.Equal(result.Deleted, []string{"logs/a.log", "logs/b&c.log", "logs/d.log"}) || len(deleted) != 2 {
+		t.Error("delete prefix error:", result.Deleted)
+	}
+	if len(aborted) != 1 || aborted[0] != "/logs/big.log" {
+		t.Error("delete prefix should abort uploads:", aborted)
+	}
+}
+
+func TestDeletePrefixCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var deleted []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.Method == "GET" && query.Get("list-type") == "2":
+			if query.Get("continuation-token") == "" {
+				fmt.Fprint(w, "<ListBucketResult><Contents><Key>logs/a.log</Key></Contents><NextContinuationToken>next</NextContinuationToken></ListBucketResult>")
+			} else {
+				fmt.Fprint(w, "<ListBucketResult><Contents><Key>logs/b.log</Key></Contents></ListBucketResult>")
+			}
+		case r.Method == "POST" && query.Has("delete"):
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			deleted = append(deleted, string(body))
+			mu.Unlock()
+			// 第一批删除进行中时取消
+			cancel()
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := New("foo", "bar", "honglei123", "cn-shanghai")
+	client.SetBucketDomain(server.URL)
+
+	result, err := client.Bucket.DeletePrefix(ctx, &client, "logs/", DeletePrefixOptions{Parallel: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Error("delete prefix should return context error:", err)
+	}
+	if len(deleted) != 1 || strings.Contains(deleted[0], "logs/b.log") {
+		t.Error("delete prefix should stop after cancel:", deleted)
+	}
+	if !slices.Equal(result.Deleted, []string{"logs/a.log"}) {
+		t.Error("delete prefix result error:", result.Deleted)
+	}
+}
@@ -0,0 +1,97 @@
+package oss
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+type ServerSideEncryptionRule struct {
+	SSEAlgorithm      string
+	KMSMasterKeyID    string
+	KMSDataEncryption string
+}
+
+type BucketInfo struct {
+	Name               string
+	CreationDate       time.Time
+	ExtranetEndpoint   string
+	IntranetEndpoint   string
+	Location           string
+	Region             string
+	StorageClass       types.StorageClass
+	DataRedundancyType types.DataRedundancyType
+	ResourceGroupId    string
+	Comment            string
+	Owner              Owner
+	ACL                types.ACL `xml:"AccessControlList>Grant"`
+	Versioning         types.VersioningStatus
+	Encryption         ServerSideEncryptionRule `xml:"ServerSideEncryptionRule"`
+}
+
+// Info 获取 bucket 的详细信息
+func (b Bucket) Info(client *Client) (BucketInfo, error) {
+	data, err := b.sub_resource_request(client, "GET", "bucketInfo", make(map[string]string), nil)
+	if err != nil {
+		return BucketInfo{}, err
+	}
+	var result struct {
+		Bucket BucketInfo
+	}
+	err = xml.Unmarshal(data, &result)
+	return result.Bucket, err
+}
+
+// Location 获取 bucket 所在的地域，例如 oss-cn-hangzhou
+func (b Bucket) Location(client *Client) (string, error) {
+	data, err := b.sub_resource_request(client, "GET", "location", make(map[string]string), nil)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		Location string `xml:",chardata"`
+	}
+	err = xml.Unmarshal(data, &result)
+	return result.Location, err
+}
+
+// BucketStat bucket 的存储容量和文件数量，容量的单位为字节
+type BucketStat struct {
+	Storage              int64
+	ObjectCount          int64
+	MultipartUploadCount int64
+	LiveChannelCount     int64
+	// 统计数据的更新时间
+	LastModifiedTime int64
+
+	StandardStorage     int64
+	StandardObjectCount int64
+
+	InfrequentAccessStorage     int64
+	InfrequentAccessRealStorage int64
+	InfrequentAccessObjectCount int64
+
+	ArchiveStorage     int64
+	ArchiveRealStorage int64
+	ArchiveObjectCount int64
+
+	ColdArchiveStorage     int64
+	ColdArchiveRealStorage int64
+	ColdArchiveObjectCount int64
+
+	DeepColdArchiveStorage     int64
+	DeepColdArchiveRealStorage int64
+	DeepColdArchiveObjectCount int64
+}
+
+// Stat 获取 bucket 的存储容量和各存储类型的文件数量，数据不是实时的
+func (b Bucket) Stat(client *Client) (BucketStat, error) {
+	data, err := b.sub_resource_request(client, "GET", "stat", make(map[string]string), nil)
+	if err != nil {
+		return BucketStat{}, err
+	}
+	var result BucketStat
+	err = xml.Unmarshal(data, &result)
+	return result, err
+}
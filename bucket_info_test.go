@@ -0,0 +1,64 @@
+package oss
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+func TestParseBucketInfo(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<BucketInfo>
+  <Bucket>
+    <CreationDate>2013-07-31T10:56:21.000Z</CreationDate>
+    <ExtranetEndpoint>oss-cn-hangzhou.aliyuncs.com</ExtranetEndpoint>
+    <IntranetEndpoint>oss-cn-hangzhou-internal.aliyuncs.com</IntranetEndpoint>
+    <Location>oss-cn-hangzhou</Location>
+    <StorageClass>Standard</StorageClass>
+    <DataRedundancyType>LRS</DataRedundancyType>
+    <Name>oss-example</Name>
+    <Owner>
+      <DisplayName>username</DisplayName>
+      <ID>27183473914****</ID>
+    </Owner>
+    <AccessControlList>
+      <Grant>private</Grant>
+    </AccessControlList>
+    <Versioning>Enabled</Versioning>
+    <ServerSideEncryptionRule>
+      <SSEAlgorithm>KMS</SSEAlgorithm>
+      <KMSMasterKeyID></KMSMasterKeyID>
+      <KMSDataEncryption>SM4</KMSDataEncryption>
+    </ServerSideEncryptionRule>
+  </Bucket>
+</BucketInfo>`
+
+	var result struct {
+		Bucket BucketInfo
+	}
+	if err := xml.Unmarshal([]byte(data), &result); err != nil {
+		t.Fatal(err)
+	}
+	info := result.Bucket
+	if info.Name != "oss-example" || info.ACL != types.ACL_PRIVATE || info.Versioning != types.VERSIONING_ENABLED {
+		t.Error("parse bucket info error")
+	}
+	if info.Encryption.SSEAlgorithm != "KMS" || info.Owner.DisplayName != "username" || info.CreationDate.Year() != 2013 {
+		t.Error("parse bucket info error")
+	}
+
+	var location struct {
+		Location string `xml:",chardata"`
+	}
+	xml.Unmarshal([]byte(`<LocationConstraint>oss-cn-hangzhou</LocationConstraint>`), &location)
+	if location.Location != "oss-cn-hangzhou" {
+		t.Error("parse bucket location error")
+	}
+
+	var stat BucketStat
+	xml.Unmarshal([]byte(`<BucketStat><Storage>1600</Storage><ObjectCount>230</ObjectCount><ArchiveObjectCount>74</ArchiveObjectCount></BucketStat>`), &stat)
+	if stat.Storage != 1600 || stat.ObjectCount != 230 || stat.ArchiveObjectCount != 74 {
+		t.Error("parse bucket stat error")
+	}
+}
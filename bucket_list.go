@@ -0,0 +1,165 @@
+package oss
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+// BucketQuery 列出 bucket 时的筛选条件
+type BucketQuery struct {
+	prefix            string
+	marker            string
+	max_keys          int
+	resource_group_id string
+}
+
+func NewBucketQuery() BucketQuery {
+	return BucketQuery{}
+}
+
+// Prefix 只列出名称以 prefix 开头的 bucket
+func (q BucketQuery) Prefix(prefix string) BucketQuery {
+	q.prefix = prefix
+	return q
+}
+
+// Marker 从名称字母序排在 marker 之后的 bucket 开始列出
+func (q BucketQuery) Marker(marker string) BucketQuery {
+	q.marker = marker
+	return q
+}
+
+// MaxKeys 每一页返回的 bucket 数量，最大为 1000
+func (q BucketQuery) MaxKeys(n int) BucketQuery {
+	q.max_keys = n
+	return q
+}
+
+// ResourceGroupId 只列出属于该资源组的 bucket
+func (q BucketQuery) ResourceGroupId(id string) BucketQuery {
+	q.resource_group_id = id
+	return q
+}
+
+func (q BucketQuery) to_oss_query(marker string) string {
+	query := url.Values{}
+	if len(q.prefix) > 0 {
+		query.Set("prefix", q.prefix)
+	}
+	if len(marker) > 0 {
+		query.Set("marker", marker)
+	}
+	if q.max_keys > 0 {
+		query.Set("max-keys", strconv.Itoa(q.max_keys))
+	}
+	return query.Encode()
+}
+
+// ListBuckets 按条件列出 endpoint 下的所有 bucket，会自动翻页
+func (c Client) ListBuckets(query BucketQuery, endpoint ...types.EndPoint) ([]Bucket, error) {
+	var end types.EndPoint
+	if len(endpoint) == 0 {
+		end = c.Bucket.endpoint
+	} else if len(endpoint) == 1 {
+		end = endpoint[0]
+	} else {
+		return []Bucket{}, errors.New("too many args")
+	}
+
+	var list []Bucket
+	marker := query.marker
+	for {
+		page, err := c.list_buckets(query, marker, end)
+		if err != nil {
+			return list, err
+		}
+		list = append(list, page.buckets...)
+		if !page.IsTruncated || len(page.NextMarker) == 0 {
+			return list, nil
+		}
+		marker = page.NextMarker
+	}
+}
+
+type list_buckets_result struct {
+	IsTruncated bool
+	NextMarker  string
+	Buckets     []BucketInfo `xml:"Buckets>Bucket"`
+	buckets     []Bucket
+}
+
+func (c Client) list_buckets(query BucketQuery, marker string, end types.EndPoint) (list_buckets_result, error) {
+	url := end.ToUrl()
+	url.RawQuery = query.to_oss_query(marker)
+	method := "GET"
+	resource := types.DefaultCanonicalizedResource()
+
+	headers := make(map[string]string)
+	if len(query.resource_group_id) > 0 {
+		headers["x-oss-resource-group-id"] = query.resource_group_id
+	}
+	headers = c.AuthorizationHeader(method, resource, headers)
+
+	req, err := http.NewRequest(method, url.String(), nil)
+	if err != nil {
+		return list_buckets_result{}, err
+	}
+
+	for k, v := range headers {
+		req.Header.Add(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return list_buckets_result{}, err
+	}
+
+	defer resp.Body.Close()
+
+	// 读取响应体
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return list_buckets_result{}, err
+	}
+
+	if !http_status_ok(resp.StatusCode) {
+		return list_buckets_result{}, c.response_error(resp, string(data))
+	}
+
+	return parse_list_buckets(data, end)
+}
+
+func parse_list_buckets(data []byte, end types.EndPoint) (list_buckets_result, error) {
+	var result list_buckets_result
+	if err := xml.Unmarshal(data, &result); err != nil {
+		return list_buckets_result{}, err
+	}
+	for _, info := range result.Buckets {
+		result.buckets = append(result.buckets, Bucket{info.Name, listed_endpoint(info, end), types.NewObjectQuery(), "", &info})
+	}
+	return result, nil
+}
+
+// listed_endpoint 使用 bucket 实际所在地域的 endpoint，并沿用 end 的内网设置；
+// end 设置了自定义域名或无法识别地域时使用 end
+func listed_endpoint(info BucketInfo, end types.EndPoint) types.EndPoint {
+	if end.HasOriginalDomain() {
+		return end
+	}
+	location := info.Location
+	if len(location) == 0 {
+		location = strings.TrimSuffix(info.ExtranetEndpoint, ".aliyuncs.com")
+	}
+	region, err := types.NewEndPointFromLocation(location, end.IsInternal())
+	if err != nil {
+		return end
+	}
+	return region
+}
@@ -0,0 +1,118 @@
+package oss
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+func TestParseListBuckets(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<ListAllMyBucketsResult>
+  <Prefix>app</Prefix>
+  <Marker></Marker>
+  <MaxKeys>1</MaxKeys>
+  <IsTruncated>true</IsTruncated>
+  <NextMarker>app-logs</NextMarker>
+  <Buckets>
+    <Bucket>
+      <CreationDate>2020-09-13T03:14:54.000Z</CreationDate>
+      <ExtranetEndpoint>oss-cn-shanghai.aliyuncs.com</ExtranetEndpoint>
+      <IntranetEndpoint>oss-cn-shanghai-internal.aliyuncs.com</IntranetEndpoint>
+      <Location>oss-cn-shanghai</Location>
+      <Name>app-logs</Name>
+      <Region>cn-shanghai</Region>
+      <StorageClass>IA</StorageClass>
+    </Bucket>
+  </Buckets>
+</ListAllMyBucketsResult>`
+	endpoint, _ := types.NewEndPoint("cn-qingdao")
+	result, err := parse_list_buckets([]byte(data), endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.IsTruncated || result.NextMarker != "app-logs" || len(result.buckets) != 1 {
+		t.Fatal("parse list buckets error")
+	}
+
+	info, ok := result.buckets[0].ListInfo()
+	if !ok || info.Region != "cn-shanghai" || info.StorageClass != types.STORAGE_CLASS_IA || info.CreationDate.Year() != 2020 {
+		t.Error("parse bucket list info error")
+	}
+	if info.ExtranetEndpoint != "oss-cn-shanghai.aliyuncs.com" || info.IntranetEndpoint != "oss-cn-shanghai-internal.aliyuncs.com" {
+		t.Error("parse bucket list endpoint error")
+	}
+
+	if result.buckets[0].endpoint.Host() != "oss-cn-shanghai.aliyuncs.com" {
+		t.Error("listed bucket endpoint error:", result.buckets[0].endpoint.Host())
+	}
+
+	internal, _ := types.NewEndPoint("cn-qingdao-internal")
+	result, _ = parse_list_buckets([]byte(data), internal)
+	if result.buckets[0].endpoint.Host() != "oss-cn-shanghai-internal.aliyuncs.com" {
+		t.Error("listed bucket internal endpoint error:", result.buckets[0].endpoint.Host())
+	}
+
+	custom, _ := types.NewEndPoint("cn-qingdao")
+	custom.SetOriginalDomain("https://oss.example.com")
+	result, _ = parse_list_buckets([]byte(data), custom)
+	if result.buckets[0].endpoint.Host() != "oss.example.com" {
+		t.Error("listed bucket custom endpoint error:", result.buckets[0].endpoint.Host())
+	}
+
+	if _, ok := (Bucket{}).ListInfo(); ok {
+		t.Error("bucket without list info")
+	}
+}
+
+func TestBucketQuery(t *testing.T) {
+	query := NewBucketQuery().Prefix("app").MaxKeys(100).ResourceGroupId("rg-1")
+	if query.to_oss_query("") != "max-keys=100&prefix=app" {
+		t.Error("bucket query error:", query.to_oss_query(""))
+	}
+	if query.to_oss_query("app-logs") != "marker=app-logs&max-keys=100&prefix=app" {
+		t.Error("bucket query with marker error")
+	}
+}
+
+func TestListBucketsPaging(t *testing.T) {
+	var markers []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-oss-resource-group-id") != "rg-1" {
+			t.Error("list buckets should send resource group header")
+		}
+		query := r.URL.Query()
+		if query.Get("prefix") != "app" {
+			t.Error("list buckets should send prefix:", r.URL.RawQuery)
+		}
+		markers = append(markers, query.Get("marker"))
+		if query.Get("marker") == "" {
+			fmt.Fprint(w, "<ListAllMyBucketsResult><IsTruncated>true</IsTruncated><NextMarker>app-a</NextMarker><Buckets><Bucket><Name>app-a</Name></Bucket></Buckets></ListAllMyBucketsResult>")
+		} else {
+			fmt.Fprint(w, "<ListAllMyBucketsResult><IsTruncated>false</IsTruncated><Buckets><Bucket><Name>app-b</Name></Bucket></Buckets></ListAllMyBucketsResult>")
+		}
+	}))
+	defer server.Close()
+
+	default_client := http.DefaultClient
+	http.DefaultClient = server.Client()
+	defer func() { http.DefaultClient = default_client }()
+
+	client, _ := New("foo", "bar", "honglei123", "cn-shanghai")
+	endpoint, _ := types.NewEndPoint("cn-shanghai")
+	endpoint.SetOriginalDomain(server.URL)
+
+	buckets, err := client.ListBuckets(NewBucketQuery().Prefix("app").ResourceGroupId("rg-1"), endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != 2 || buckets[0].name != "app-a" || buckets[1].name != "app-b" {
+		t.Error("list buckets paging error:", buckets)
+	}
+	if len(markers) != 2 || markers[0] != "" || markers[1] != "app-a" {
+		t.Error("list buckets should follow NextMarker:", markers)
+	}
+}
@@ -0,0 +1,101 @@
+package oss
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+// MultipartUpload 已初始化但还没有完成或取消的分片上传
+type MultipartUpload struct {
+	Key       string
+	UploadId  string
+	Initiated time.Time
+	bucket    Bucket
+}
+
+// Abort 取消这个分片上传
+func (u MultipartUpload) Abort(client *Client) error {
+	parts := NewPartsUpload(u.Key).Bucket(u.bucket)
+	parts.upload_id = u.UploadId
+	return parts.Abort(client)
+}
+
+// ListMultipartUploads 列出 prefix 下所有进行中的分片上传，会自动翻页
+func (b Bucket) ListMultipartUploads(client *Client, prefix string) ([]MultipartUpload, error) {
+	var list []MultipartUpload
+	key_marker, upload_id_marker := "", ""
+	for {
+		page, err := b.list_multipart_uploads(client, prefix, key_marker, upload_id_marker)
+		if err != nil {
+			return list, err
+		}
+		for _, upload := range page.Uploads {
+			upload.bucket = b
+			list = append(list, upload)
+		}
+		if !page.IsTruncated {
+			return list, nil
+		}
+		key_marker, upload_id_marker = page.NextKeyMarker, page.NextUploadIdMarker
+	}
+}
+
+type list_multipart_uploads_result struct {
+	IsTruncated        bool
+	NextKeyMarker      string
+	NextUploadIdMarker string
+	Uploads            []MultipartUpload `xml:"Upload"`
+}
+
+func (b Bucket) list_multipart_uploads(client *Client, prefix, key_marker, upload_id_marker string) (list_multipart_uploads_result, error) {
+	query := url.Values{}
+	query.Set("prefix", prefix)
+	query.Set("max-uploads", "1000")
+	if len(key_marker) > 0 {
+		query.Set("key-marker", key_marker)
+		query.Set("upload-id-marker", upload_id_marker)
+	}
+
+	u := b.ToUrl()
+	u.RawQuery = "uploads&" + query.Encode()
+	method := "GET"
+
+	resource := types.NewCanonicalizedResource(fmt.Sprintf("/%s/?uploads", b.name))
+	headers := client.Authorization(method, resource)
+
+	req, err := http.NewRequest(method, u.String(), nil)
+	if err != nil {
+		return list_multipart_uploads_result{}, err
+	}
+
+	for k, v := range headers {
+		req.Header.Add(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return list_multipart_uploads_result{}, err
+	}
+
+	defer resp.Body.Close()
+
+	// 读取响应体
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return list_multipart_uploads_result{}, err
+	}
+
+	if !http_status_ok(resp.StatusCode) {
+		return list_multipart_uploads_result{}, client.response_error(resp, string(data))
+	}
+
+	var result list_multipart_uploads_result
+	err = xml.Unmarshal(data, &result)
+	return result, err
+}
@@ -0,0 +1,46 @@
+package oss
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+// sub_resource_request 发送 bucket 的 ?versioning、?bucketInfo 等子资源请求，返回响应体
+func (b Bucket) sub_resource_request(client *Client, method, sub_resource string, headers map[string]string, body []byte) ([]byte, error) {
+	url := b.ToUrl()
+	url.RawQuery = sub_resource
+
+	resource := types.NewCanonicalizedResource(fmt.Sprintf("/%s/?%s", b.name, sub_resource))
+	headers = client.AuthorizationHeader(method, resource, headers)
+
+	req, err := http.NewRequest(method, url.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range headers {
+		req.Header.Add(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	// 读取响应体
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if !http_status_ok(resp.StatusCode) {
+		return nil, client.response_error(resp, string(data))
+	}
+	return data, nil
+}
@@ -0,0 +1,87 @@
+package oss
+
+import (
+	"context"
+	"encoding/xml"
+	"strconv"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+type versioning_configuration struct {
+	XMLName xml.Name               `xml:"VersioningConfiguration"`
+	Status  types.VersioningStatus `xml:",omitempty"`
+}
+
+// PutVersioning 开启或暂停 bucket 的版本控制，开启后不能再关闭，只能暂停
+func (b Bucket) PutVersioning(client *Client, status types.VersioningStatus) error {
+	body, err := xml.Marshal(versioning_configuration{Status: status})
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{
+		"Content-Length": strconv.Itoa(len(body)),
+		"Content-MD5":    content_md5(body),
+	}
+	_, err = b.sub_resource_request(client, "PUT", "versioning", headers, body)
+	return err
+}
+
+// GetVersioning 获取 bucket 的版本控制状态，从未开启过时返回空字符串
+func (b Bucket) GetVersioning(client *Client) (types.VersioningStatus, error) {
+	data, err := b.sub_resource_request(client, "GET", "versioning", make(map[string]string), nil)
+	if err != nil {
+		return "", err
+	}
+	var result versioning_configuration
+	err = xml.Unmarshal(data, &result)
+	return result.Status, err
+}
+
+// PurgeNoncurrentVersions 删除 prefix 下所有的历史版本和不是最新的删除标记，
+// 每个文件的当前版本保持不变
+func (b Bucket) PurgeNoncurrentVersions(ctx context.Context, client *Client, prefix string) (DeleteObjectsResult, error) {
+	var result DeleteObjectsResult
+
+	query := map[string]string{
+		types.QUERY_PREFIX:   prefix,
+		types.QUERY_MAX_KEYS: strconv.Itoa(DELETE_BATCH_SIZE),
+	}
+	versions, err := b.ListObjectVersions(client, query)
+	for err == nil {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		keys := noncurrent_versions(versions)
+		if len(keys) > 0 {
+			res, err := b.DeleteObjects(client, keys, false)
+			result.Deleted = append(result.Deleted, res.Deleted...)
+			result.Errors = append(result.Errors, res.Errors...)
+			if err != nil {
+				return result, err
+			}
+		}
+
+		if !versions.IsTruncated {
+			return result, nil
+		}
+		versions, err = versions.NextList(client)
+	}
+	return result, err
+}
+
+func noncurrent_versions(versions ObjectVersions) []DeleteKey {
+	var keys []DeleteKey
+	for _, version := range versions.Versions {
+		if !version.IsLatest {
+			keys = append(keys, DeleteKey{version.Key, version.VersionId})
+		}
+	}
+	for _, marker := range versions.DeleteMarkers {
+		if !marker.IsLatest {
+			keys = append(keys, DeleteKey{marker.Key, marker.VersionId})
+		}
+	}
+	return keys
+}
@@ -0,0 +1,46 @@
+package oss
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+func TestVersioningConfiguration(t *testing.T) {
+	data, _ := xml.Marshal(versioning_configuration{Status: types.VERSIONING_ENABLED})
+	if string(data) != "<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>" {
+		t.Error("versioning xml error:", string(data))
+	}
+
+	var result versioning_configuration
+	xml.Unmarshal([]byte("<VersioningConfiguration/>"), &result)
+	if result.Status != "" {
+		t.Error("never enabled versioning should be empty")
+	}
+}
+
+func TestNoncurrentVersions(t *testing.T) {
+	versions := ObjectVersions{
+		Versions: []ObjectVersion{
+			{Key: "a.log", VersionId: "3", IsLatest: true},
+			{Key: "a.log", VersionId: "2"},
+			{Key: "b.log", VersionId: "1"},
+		},
+		DeleteMarkers: []DeleteMarkerEntry{
+			{Key: "b.log", VersionId: "5", IsLatest: true},
+			{Key: "c.log", VersionId: "4"},
+		},
+	}
+
+	keys := noncurrent_versions(versions)
+	expected := []DeleteKey{{"a.log", "2"}, {"b.log", "1"}, {"c.log", "4"}}
+	if len(keys) != len(expected) {
+		t.Fatal("noncurrent versions error:", keys)
+	}
+	for i := range keys {
+		if keys[i] != expected[i] {
+			t.Error("noncurrent versions error:", keys)
+		}
+	}
+}
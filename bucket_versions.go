@@ -0,0 +1,133 @@
+package oss
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+// ObjectVersion 文件的一个版本
+type ObjectVersion struct {
+	Key          string
+	VersionId    string
+	IsLatest     bool
+	LastModified time.Time
+	ETag         string
+	Size         int64
+	StorageClass types.StorageClass
+	Type         string
+	bucket       Bucket
+}
+
+// Object 返回指向这个版本的 Object
+func (v ObjectVersion) Object() Object {
+	return NewObject(v.Key).Bucket(v.bucket).VersionId(v.VersionId)
+}
+
+// DeleteMarkerEntry 删除文件时产生的删除标记
+type DeleteMarkerEntry struct {
+	Key          string
+	VersionId    string
+	IsLatest     bool
+	LastModified time.Time
+	bucket       Bucket
+}
+
+// Object 返回指向这个删除标记的 Object，删除它可以恢复文件的上一个版本
+func (d DeleteMarkerEntry) Object() Object {
+	return NewObject(d.Key).Bucket(d.bucket).VersionId(d.VersionId)
+}
+
+type ObjectVersions struct {
+	Versions            []ObjectVersion     `xml:"Version"`
+	DeleteMarkers       []DeleteMarkerEntry `xml:"DeleteMarker"`
+	IsTruncated         bool
+	NextKeyMarker       string
+	NextVersionIdMarker string
+	query               map[string]string
+	bucket              Bucket
+}
+
+// NextList 获取下一页的版本列表
+func (v ObjectVersions) NextList(client *Client) (ObjectVersions, error) {
+	if !v.IsTruncated {
+		return ObjectVersions{}, &NoFoundMoreObject{}
+	}
+	query := make(map[string]string, len(v.query)+2)
+	for key, value := range v.query {
+		query[key] = value
+	}
+	query[types.QUERY_KEY_MARKER] = v.NextKeyMarker
+	query[types.QUERY_VERSION_ID_MARKER] = v.NextVersionIdMarker
+	return v.bucket.ListObjectVersions(client, query)
+}
+
+// ListObjectVersions 列出文件的所有版本和删除标记，query 支持 prefix、delimiter、
+// max-keys、key-marker、version-id-marker，使用 NextList 翻页
+func (b Bucket) ListObjectVersions(client *Client, query map[string]string) (ObjectVersions, error) {
+	values := url.Values{}
+	for key, value := range query {
+		values.Set(key, value)
+	}
+
+	u := b.ToUrl()
+	u.RawQuery = "versions"
+	if len(values) > 0 {
+		u.RawQuery += "&" + values.Encode()
+	}
+	method := "GET"
+
+	resource := types.NewCanonicalizedResource(fmt.Sprintf("/%s/?versions", b.name))
+	headers := client.Authorization(method, resource)
+
+	req, err := http.NewRequest(method, u.String(), nil)
+	if err != nil {
+		return ObjectVersions{}, err
+	}
+
+	for k, v := range headers {
+		req.Header.Add(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return ObjectVersions{}, err
+	}
+
+	defer resp.Body.Close()
+
+	// 读取响应体
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ObjectVersions{}, err
+	}
+
+	if !http_status_ok(resp.StatusCode) {
+		return ObjectVersions{}, client.response_error(resp, string(data))
+	}
+
+	result, err := parse_object_versions(data, b)
+	result.query = query
+	return result, err
+}
+
+func parse_object_versions(data []byte, bucket Bucket) (ObjectVersions, error) {
+	var result ObjectVersions
+	if err := xml.Unmarshal(data, &result); err != nil {
+		return ObjectVersions{}, err
+	}
+	result.bucket = bucket
+	for i := range result.Versions {
+		result.Versions[i].ETag = trim_etag(result.Versions[i].ETag)
+		result.Versions[i].bucket = bucket
+	}
+	for i := range result.DeleteMarkers {
+		result.DeleteMarkers[i].bucket = bucket
+	}
+	return result, nil
+}
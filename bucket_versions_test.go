@@ -0,0 +1,54 @@
+package oss
+
+import (
+	"testing"
+)
+
+func TestParseObjectVersions(t *testing.T) {
+	xml := `<?xml version="1.0" encoding="UTF-8"?>
+<ListVersionsResult>
+  <Name>honglei123</Name>
+  <Prefix>logs/</Prefix>
+  <MaxKeys>2</MaxKeys>
+  <IsTruncated>true</IsTruncated>
+  <NextKeyMarker>logs/b.log</NextKeyMarker>
+  <NextVersionIdMarker>CAEQ2</NextVersionIdMarker>
+  <DeleteMarker>
+    <Key>logs/a.log</Key>
+    <VersionId>CAEQ1</VersionId>
+    <IsLatest>true</IsLatest>
+    <LastModified>2019-04-09T07:27:28.000Z</LastModified>
+  </DeleteMarker>
+  <Version>
+    <Key>logs/a.log</Key>
+    <VersionId>CAEQ0</VersionId>
+    <IsLatest>false</IsLatest>
+    <LastModified>2019-04-09T07:27:28.000Z</LastModified>
+    <ETag>"250F8A0AE989679A22926A875F0A2****"</ETag>
+    <Type>Normal</Type>
+    <Size>93731</Size>
+    <StorageClass>Standard</StorageClass>
+  </Version>
+</ListVersionsResult>`
+
+	bucket, _ := NewBucket("honglei123", "cn-shanghai")
+	versions, err := parse_object_versions([]byte(xml), bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !versions.IsTruncated || versions.NextVersionIdMarker != "CAEQ2" || len(versions.DeleteMarkers) != 1 || len(versions.Versions) != 1 {
+		t.Error("parse object versions error")
+	}
+	version := versions.Versions[0]
+	if version.ETag != "250F8A0AE989679A22926A875F0A2****" || version.Size != 93731 || version.IsLatest {
+		t.Error("parse object version error")
+	}
+
+	obj := versions.DeleteMarkers[0].Object()
+	if obj.version_query("acl") != "acl&versionId=CAEQ1" {
+		t.Error("version query error:", obj.version_query("acl"))
+	}
+	if canonicalized_resource_version(&bucket, &obj, "").ToStr() != "/honglei123/logs/a.log?versionId=CAEQ1" {
+		t.Error("version resource error")
+	}
+}
@@ -0,0 +1,88 @@
+package oss
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// clock 记录本地时间与 oss 服务器时间的偏差，同一个 Client 的所有副本共享
+type clock struct {
+	now    func() time.Time
+	offset atomic.Int64
+}
+
+func new_clock() *clock {
+	return &clock{now: time.Now}
+}
+
+func (c *clock) time() time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return c.now().Add(time.Duration(c.offset.Load()))
+}
+
+// adjust 根据服务器返回的 Date 响应头修正时间偏差
+func (c *clock) adjust(date string) {
+	if c == nil || len(date) == 0 {
+		return
+	}
+	server, err := http.ParseTime(date)
+	if err != nil {
+		return
+	}
+	c.offset.Store(int64(server.Sub(c.now())))
+}
+
+// oss 允许的请求时间与服务器时间的最大偏差
+const MAX_CLOCK_SKEW = 15 * time.Minute
+
+// skewed 服务器返回的 Date 与签名使用的时间相差是否超过 MAX_CLOCK_SKEW
+func (c *clock) skewed(date string) bool {
+	server, err := http.ParseTime(date)
+	if err != nil {
+		return false
+	}
+	diff := server.Sub(c.time())
+	return diff > MAX_CLOCK_SKEW || diff < -MAX_CLOCK_SKEW
+}
+
+// SetClock 设置签名使用的时钟，主要用于测试中生成固定的签名
+func (c *Client) SetClock(now func() time.Time) {
+	c.clock = &clock{now: now}
+}
+
+// ClockOffset 返回当前使用的时间偏差（服务器时间 - 本地时间）
+func (c Client) ClockOffset() time.Duration {
+	if c.clock == nil {
+		return 0
+	}
+	return time.Duration(c.clock.offset.Load())
+}
+
+func (c Client) now() string {
+	// 获取当前时间并转换为 UTC
+	currentTime := c.clock.time().UTC()
+
+	// 格式化时间为 RFC1123 格式（带 GMT）
+	formattedTime := currentTime.Format(time.RFC1123)
+
+	// 将 "UTC" 替换为 "GMT"
+	formattedTime = replaceUTCWithGMT(formattedTime)
+	return formattedTime
+}
+
+// response_error 解析 oss 返回的错误，遇到 RequestTimeTooSkewed 时修正时间偏差，
+// 之后该 Client 的请求会使用服务器的时间签名。HEAD 请求的错误没有 body，
+// 403 且服务器时间相差超过 MAX_CLOCK_SKEW 时同样修正
+func (c Client) response_error(resp *http.Response, body string) *OssResponseError {
+	e := parse_oss_response_error(resp, body)
+	date := resp.Header.Get("Date")
+	if e.Code == "RequestTimeTooSkewed" {
+		c.clock.adjust(date)
+	} else if len(body) == 0 && resp.StatusCode == http.StatusForbidden && c.clock.skewed(date) {
+		c.clock.adjust(date)
+	}
+	return e
+}
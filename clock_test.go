@@ -0,0 +1,70 @@
+package oss
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+func TestClockSkew(t *testing.T) {
+	client, _ := New("foo", "bar", "honglei123", "cn-shanghai")
+	client.SetClock(func() time.Time {
+		return time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	})
+
+	headers := client.Authorization("GET", types.DefaultCanonicalizedResource())
+	if headers["Date"] != "Fri, 01 Mar 2024 08:00:00 GMT" {
+		t.Error("signing date error:", headers["Date"])
+	}
+	if headers["Authorization"] != "OSS foo:"+types.NewSecret("bar").Encryption("GET\n\n\nFri, 01 Mar 2024 08:00:00 GMT\n/") {
+		t.Error("signature error")
+	}
+
+	resp := &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}
+	resp.Header.Set("Date", "Fri, 01 Mar 2024 08:15:00 GMT")
+	client.response_error(resp, "<Error><Code>RequestTimeTooSkewed</Code></Error>")
+
+	if client.ClockOffset() != 15*time.Minute {
+		t.Error("clock offset error:", client.ClockOffset())
+	}
+	headers = client.Authorization("GET", types.DefaultCanonicalizedResource())
+	if headers["Date"] != "Fri, 01 Mar 2024 08:15:00 GMT" {
+		t.Error("signing date should use server time:", headers["Date"])
+	}
+
+	// HEAD 请求的错误没有 body，根据 Date 判断时间偏差
+	client.SetClock(func() time.Time {
+		return time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	})
+	resp = &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}
+	resp.Header.Set("Date", "Fri, 01 Mar 2024 08:05:00 GMT")
+	client.response_error(resp, "")
+	if client.ClockOffset() != 0 {
+		t.Error("small difference should not adjust clock:", client.ClockOffset())
+	}
+
+	resp.Header.Set("Date", "Fri, 01 Mar 2024 09:00:00 GMT")
+	client.response_error(resp, "")
+	if client.ClockOffset() != time.Hour {
+		t.Error("head clock offset error:", client.ClockOffset())
+	}
+
+	// 真实的 HEAD 请求，服务器时间比签名时间晚很多
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+	client.SetClock(func() time.Time {
+		return time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	})
+	client.SetBucketDomain(server.URL)
+	if _, err := NewObject("a.txt").Head(&client); err == nil {
+		t.Fatal("head should fail")
+	}
+	if client.ClockOffset() < time.Hour {
+		t.Error("head should adjust clock:", client.ClockOffset())
+	}
+}
@@ -0,0 +1,36 @@
+package oss
+
+import (
+	"net/url"
+)
+
+// CopySource 复制操作的源文件，可以是同一地域下任意 bucket 中的文件
+type CopySource struct {
+	bucket     Bucket
+	key        string
+	version_id string
+}
+
+func NewCopySource(bucket Bucket, key string) CopySource {
+	return CopySource{bucket, key, ""}
+}
+
+// VersionId 复制源文件的指定版本
+func (s CopySource) VersionId(id string) CopySource {
+	s.version_id = id
+	return s
+}
+
+// String 返回 x-oss-copy-source 请求头的值，文件名会经过 url 编码
+func (s CopySource) String() string {
+	source := copy_source(s.bucket.name, s.key)
+	if len(s.version_id) > 0 {
+		source += "?versionId=" + url.QueryEscape(s.version_id)
+	}
+	return source
+}
+
+// object 返回源文件对应的 Object，用于获取源文件的元信息
+func (s CopySource) object() Object {
+	return NewObject(s.key).Bucket(s.bucket).VersionId(s.version_id)
+}
@@ -0,0 +1,23 @@
+package oss
+
+import "testing"
+
+func TestCopySource(t *testing.T) {
+	bucket, _ := NewBucket("honglei123", "cn-shanghai")
+	other, _ := NewBucket("aliyun-wb-kpbf3", "cn-shanghai")
+	client, _ := New("foo", "bar", "honglei123", "cn-shanghai")
+
+	source := NewCopySource(other, "logs/2024/a+b.log").VersionId("CAEQ=")
+	if source.String() != "/aliyun-wb-kpbf3/logs%2F2024%2Fa%2Bb.log?versionId=CAEQ%3D" {
+		t.Error("copy source error:", source.String())
+	}
+
+	obj := NewObject("a.log").CopyFrom(NewCopySource(bucket, "a.log"))
+	if obj.get_bucket(&client).name != "honglei123" {
+		t.Error("object should use client bucket by default")
+	}
+	obj = obj.Bucket(other)
+	if obj.get_bucket(&client).name != "aliyun-wb-kpbf3" {
+		t.Error("object should use the given bucket")
+	}
+}
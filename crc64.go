@@ -0,0 +1,112 @@
+package oss
+
+import (
+	"fmt"
+	"hash/crc64"
+	"net/http"
+	"strconv"
+)
+
+// oss 使用的 crc64 算法（CRC-64/XZ），与 hash/crc64 的 ECMA 表一致
+var crc_table = crc64.MakeTable(crc64.ECMA)
+
+func crc64_checksum(data []byte) uint64 {
+	return crc64.Checksum(data, crc_table)
+}
+
+// crc64_combine 根据 crc(A)、crc(B) 和 B 的长度计算 crc(A+B)，
+// 分片上传完成时用来合并每个分片的 crc，算法来自 zlib 的 crc32_combine
+func crc64_combine(crc1, crc2, len2 uint64) uint64 {
+	if len2 == 0 {
+		return crc1
+	}
+
+	even := make([]uint64, 64) // 偶数次幂的零比特运算矩阵
+	odd := make([]uint64, 64)  // 奇数次幂的零比特运算矩阵
+
+	// 一个零比特的运算矩阵
+	odd[0] = 0xC96C5795D7870F42 // ECMA 多项式的反转形式
+	row := uint64(1)
+	for n := 1; n < 64; n++ {
+		odd[n] = row
+		row <<= 1
+	}
+
+	gf2_matrix_square(even, odd) // 两个零比特
+	gf2_matrix_square(odd, even) // 四个零比特
+
+	// 每次循环平方一次矩阵，对 len2 的每个比特位应用一次
+	for {
+		gf2_matrix_square(even, odd)
+		if len2&1 != 0 {
+			crc1 = gf2_matrix_times(even, crc1)
+		}
+		len2 >>= 1
+		if len2 == 0 {
+			break
+		}
+
+		gf2_matrix_square(odd, even)
+		if len2&1 != 0 {
+			crc1 = gf2_matrix_times(odd, crc1)
+		}
+		len2 >>= 1
+		if len2 == 0 {
+			break
+		}
+	}
+
+	return crc1 ^ crc2
+}
+
+func gf2_matrix_times(mat []uint64, vec uint64) uint64 {
+	var sum uint64
+	for i := 0; vec != 0; i++ {
+		if vec&1 != 0 {
+			sum ^= mat[i]
+		}
+		vec >>= 1
+	}
+	return sum
+}
+
+func gf2_matrix_square(square, mat []uint64) {
+	for n := 0; n < 64; n++ {
+		square[n] = gf2_matrix_times(mat, mat[n])
+	}
+}
+
+// CRCMismatchError 本地计算的 crc64 与 oss 返回的 x-oss-hash-crc64ecma 不一致
+type CRCMismatchError struct {
+	ClientCRC uint64
+	ServerCRC uint64
+	RequestId string
+}
+
+func (e *CRCMismatchError) Error() string {
+	return fmt.Sprintf("crc64 mismatch: client %d, server %d (request id %s)", e.ClientCRC, e.ServerCRC, e.RequestId)
+}
+
+// check_crc64 比较本地 crc 与响应头中的 crc，响应头中没有 crc 时不做校验
+func (c Client) check_crc64(client_crc uint64, header http.Header) error {
+	if c.disable_crc {
+		return nil
+	}
+	value := header.Get("x-oss-hash-crc64ecma")
+	if len(value) == 0 {
+		return nil
+	}
+	server_crc, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	if server_crc != client_crc {
+		return &CRCMismatchError{client_crc, server_crc, header.Get("x-oss-request-id")}
+	}
+	return nil
+}
+
+// EnableCRC 设置是否校验上传、下载数据的 crc64，默认开启
+func (c *Client) EnableCRC(enable bool) {
+	c.disable_crc = !enable
+}
@@ -0,0 +1,77 @@
+package oss
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestCrc64Combine(t *testing.T) {
+	a := []byte("hello oss,")
+	b := []byte(" this is the second part")
+
+	if crc64_checksum([]byte("123456789")) != 0x995DC9BBDF1939FA {
+		t.Error("crc64 checksum error")
+	}
+
+	whole := crc64_checksum(append(append([]byte{}, a...), b...))
+	if crc64_combine(crc64_checksum(a), crc64_checksum(b), uint64(len(b))) != whole {
+		t.Error("crc64 combine error")
+	}
+	if crc64_combine(0, crc64_checksum(a), uint64(len(a))) != crc64_checksum(a) {
+		t.Error("crc64 combine with empty error")
+	}
+}
+
+func TestCheckCrc64(t *testing.T) {
+	client, _ := New("foo", "bar", "honglei123", "cn-shanghai")
+	header := http.Header{}
+	header.Set("x-oss-hash-crc64ecma", "1")
+
+	var crc_err *CRCMismatchError
+	if err := client.check_crc64(2, header); !errors.As(err, &crc_err) {
+		t.Error("crc64 mismatch should return CRCMismatchError")
+	}
+
+	client.EnableCRC(false)
+	if client.check_crc64(2, header) != nil {
+		t.Error("disabled crc64 should not check")
+	}
+}
+
+func TestDownloadGzipObject(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte("hello gzip"))
+	zw.Close()
+	stored := buf.Bytes()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("x-oss-hash-crc64ecma", strconv.FormatUint(crc64_checksum(stored), 10))
+		w.Write(stored)
+	}))
+	defer server.Close()
+
+	client, _ := New("foo", "bar", "honglei123", "cn-shanghai")
+	client.SetBucketDomain(server.URL)
+
+	data, err := NewObject("a.txt").Download(&client)
+	if err != nil || string(data) != "hello gzip" {
+		t.Error("download gzip object error:", err)
+	}
+
+	file := filepath.Join(t.TempDir(), "a.txt")
+	if err := NewPartsDownload("a.txt").FilePath(file).Download(&client); err != nil {
+		t.Error("parts download gzip object error:", err)
+	}
+	if data, _ := os.ReadFile(file); string(data) != "hello gzip" {
+		t.Error("parts download gzip content error:", string(data))
+	}
+}
@@ -1,32 +1,114 @@
 package oss
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/http"
 
-type EnvEmtpyError struct {
-	// name string
-}
+	"github.com/tu6ge/oss-go/types"
+)
+
+// EnvEmtpyError 环境变量缺失，Names 为该配置项可用的所有变量名
+type EnvEmtpyError = types.EnvEmtpyError
+
+// 常见的 oss 错误码，可以使用 errors.Is(err, oss.ErrNoSuchKey) 判断
+var (
+	ErrNoSuchKey                = errors.New("NoSuchKey")
+	ErrNoSuchBucket             = errors.New("NoSuchBucket")
+	ErrAccessDenied             = errors.New("AccessDenied")
+	ErrBucketAlreadyExists      = errors.New("BucketAlreadyExists")
+	ErrNoSuchUpload             = errors.New("NoSuchUpload")
+	ErrRequestTimeTooSkewed     = errors.New("RequestTimeTooSkewed")
+	ErrInvalidAccessKeyId       = errors.New("InvalidAccessKeyId")
+	ErrSignatureDoesNotMatch    = errors.New("SignatureDoesNotMatch")
+	ErrFileAlreadyExists        = errors.New("FileAlreadyExists")
+	ErrInvalidObjectState       = errors.New("InvalidObjectState")
+	ErrPositionNotEqualLength   = errors.New("PositionNotEqualToLength")
+	ErrRestoreAlreadyInProgress = errors.New("RestoreAlreadyInProgress")
+	ErrBucketNotEmpty           = errors.New("BucketNotEmpty")
+	ErrTooManyBuckets           = errors.New("TooManyBuckets")
+)
 
-// NameEmtpyError实现了 Error() 方法的对象都可以
-func (e *EnvEmtpyError) Error() string {
-	return "ALIYUN_KEY_ID or ALIYUN_KEY_SECRET is not empty"
+var code_errors = map[string]error{
+	"NoSuchKey":                ErrNoSuchKey,
+	"NoSuchBucket":             ErrNoSuchBucket,
+	"AccessDenied":             ErrAccessDenied,
+	"BucketAlreadyExists":      ErrBucketAlreadyExists,
+	"NoSuchUpload":             ErrNoSuchUpload,
+	"RequestTimeTooSkewed":     ErrRequestTimeTooSkewed,
+	"InvalidAccessKeyId":       ErrInvalidAccessKeyId,
+	"SignatureDoesNotMatch":    ErrSignatureDoesNotMatch,
+	"FileAlreadyExists":        ErrFileAlreadyExists,
+	"InvalidObjectState":       ErrInvalidObjectState,
+	"PositionNotEqualToLength": ErrPositionNotEqualLength,
+	"RestoreAlreadyInProgress": ErrRestoreAlreadyInProgress,
+	"BucketNotEmpty":           ErrBucketNotEmpty,
+	"TooManyBuckets":           ErrTooManyBuckets,
 }
 
 type OssResponseError struct {
+	StatusCode   int
 	Code         string
 	Message      string
 	RequestId    string
+	HostId       string
+	EC           string
 	RecommendDoc string
+	Header       http.Header
+}
+
+func parse_oss_response_error(resp *http.Response, xml string) *OssResponseError {
+	e := &OssResponseError{
+		StatusCode:   resp.StatusCode,
+		Code:         parse_item(xml, "Code"),
+		Message:      parse_item(xml, "Message"),
+		RequestId:    parse_item(xml, "RequestId"),
+		HostId:       parse_item(xml, "HostId"),
+		EC:           parse_item(xml, "EC"),
+		RecommendDoc: parse_item(xml, "RecommendDoc"),
+		Header:       resp.Header,
+	}
+
+	// HEAD 等请求的响应没有 body，只能从响应头和状态码中获取信息
+	if len(e.RequestId) == 0 {
+		e.RequestId = resp.Header.Get("x-oss-request-id")
+	}
+	if len(e.EC) == 0 {
+		e.EC = resp.Header.Get("x-oss-ec")
+	}
+	if len(e.Code) == 0 {
+		e.Code = status_code(resp.StatusCode, e.EC)
+	}
+	if len(e.Message) == 0 {
+		e.Message = http.StatusText(resp.StatusCode)
+	}
+
+	return e
 }
 
-func parse_oss_response_error(xml string) *OssResponseError {
-	code := parse_item(xml, "Code")
-	message := parse_item(xml, "Message")
-	request_id := parse_item(xml, "RequestId")
-	recommend_doc := parse_item(xml, "RecommendDoc")
+// x-oss-ec 与错误码的对应关系
+var ec_codes = map[string]string{
+	"0015-00000101": "NoSuchBucket",
+	"0026-00000001": "NoSuchKey",
+}
 
-	return &OssResponseError{code, message, request_id, recommend_doc}
+// status_code 优先根据 x-oss-ec 确定错误码，404 无法区分 bucket 和文件不存在，
+// 没有 x-oss-ec 时不设置错误码
+func status_code(status int, ec string) string {
+	if code, ok := ec_codes[ec]; ok {
+		return code
+	}
+	if status == http.StatusForbidden {
+		return "AccessDenied"
+	}
+	return ""
 }
 
 func (e *OssResponseError) Error() string {
-	return fmt.Sprintf("oss return: %s", e.Message)
+	return fmt.Sprintf("oss return: %s: %s (status %d, request id %s)", e.Code, e.Message, e.StatusCode, e.RequestId)
+}
+
+func (e *OssResponseError) Is(target error) bool {
+	sentinel, ok := code_errors[e.Code]
+	return ok && sentinel == target
 }
@@ -0,0 +1,58 @@
+package oss
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestParseOssResponseError(t *testing.T) {
+	xml := `<?xml version="1.0" encoding="UTF-8"?>
+<Error>
+  <Code>NoSuchKey</Code>
+  <Message>The specified key does not exist.</Message>
+  <RequestId>5C3D9175B6FC201293AD****</RequestId>
+  <HostId>test.oss-cn-hangzhou.aliyuncs.com</HostId>
+  <Key>abc.txt</Key>
+  <EC>0026-00000001</EC>
+</Error>`
+	resp := &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}}
+	err := parse_oss_response_error(resp, xml)
+
+	if err.Code != "NoSuchKey" || err.EC != "0026-00000001" || err.HostId != "test.oss-cn-hangzhou.aliyuncs.com" {
+		t.Error("parse oss response error failed")
+	}
+	if !errors.Is(err, ErrNoSuchKey) || errors.Is(err, ErrNoSuchBucket) {
+		t.Error("oss response error should match sentinel by code")
+	}
+
+	// HEAD 请求没有 body
+	resp = &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}
+	resp.Header.Set("x-oss-request-id", "abc")
+	resp.Header.Set("x-oss-ec", "0003-00000001")
+	err = parse_oss_response_error(resp, "")
+	if err.RequestId != "abc" || err.EC != "0003-00000001" || !errors.Is(err, ErrAccessDenied) {
+		t.Error("parse oss response error from header failed")
+	}
+	if err.Error() != "oss return: AccessDenied: Forbidden (status 403, request id abc)" {
+		t.Error("oss response error message error:", err.Error())
+	}
+
+	resp = &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}}
+	resp.Header.Set("x-oss-ec", "0015-00000101")
+	err = parse_oss_response_error(resp, "")
+	if !errors.Is(err, ErrNoSuchBucket) || errors.Is(err, ErrNoSuchKey) {
+		t.Error("head on missing bucket should be NoSuchBucket")
+	}
+
+	resp.Header.Set("x-oss-ec", "0026-00000001")
+	if !errors.Is(parse_oss_response_error(resp, ""), ErrNoSuchKey) {
+		t.Error("head on missing key should be NoSuchKey")
+	}
+
+	resp.Header.Del("x-oss-ec")
+	err = parse_oss_response_error(resp, "")
+	if err.Code != "" || errors.Is(err, ErrNoSuchKey) || errors.Is(err, ErrNoSuchBucket) {
+		t.Error("404 without x-oss-ec should not guess the code")
+	}
+}
@@ -16,7 +16,7 @@ func main() {
 
 	object := oss.NewPartsUpload("video222.mov")
 
-	err = object.FilePath("./video.mov").Upload(&client)
+	_, err = object.FilePath("./video.mov").Upload(&client)
 	if err != nil {
 		fmt.Println("error:", err)
 	}
@@ -70,7 +70,7 @@ func main() {
 	// 使用文件内容上传文件
 	content := []byte("foo")
 
-	err = obj.Content(content).ContentType("text/plain;charset=utf-8").Upload(&client)
+	_, err = obj.Content(content).ContentType("text/plain;charset=utf-8").Upload(&client)
 	if err != nil {
 		fmt.Println(err)
 		return
@@ -84,14 +84,14 @@ func main() {
 	}
 	defer f.Close()
 
-	err = oss.NewObject("from_file.txt").File(f).ContentType("text/plain;charset=utf-8").Upload(&client)
+	_, err = oss.NewObject("from_file.txt").File(f).ContentType("text/plain;charset=utf-8").Upload(&client)
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
 
 	// 使用本地文件路径上传文件
-	err = oss.NewObject("from_file2.txt").FilePath("./demofile.txt").ContentType("text/plain;charset=utf-8").Upload(&client)
+	_, err = oss.NewObject("from_file2.txt").FilePath("./demofile.txt").ContentType("text/plain;charset=utf-8").Upload(&client)
 	if err != nil {
 		fmt.Println(err)
 		return
@@ -107,14 +107,14 @@ func main() {
 
 	// 复制文件
 	obj_copy := oss.NewObject("xyz.html")
-	err = obj_copy.CopySource("/honglei123/aaabbc.html").ContentType("text/plain;charset=utf-8").Copy(&client)
+	_, err = obj_copy.CopySource("/honglei123/aaabbc.html").ContentType("text/plain;charset=utf-8").Copy(&client)
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
 
 	// 删除文件
-	err = obj.Delete(&client)
+	_, err = obj.Delete(&client)
 	if err != nil {
 		fmt.Println(err)
 		return
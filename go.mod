@@ -2,4 +2,4 @@ module github.com/tu6ge/oss-go
 
 go 1.24.1
 
-require github.com/joho/godotenv v1.5.1 // indirect
+require github.com/joho/godotenv v1.5.1
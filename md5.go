@@ -0,0 +1,45 @@
+package oss
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// content_md5 返回 Content-MD5 请求头使用的 base64 编码的 md5 值
+func content_md5(data []byte) string {
+	sum := md5.Sum(data)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// MD5MismatchError 普通上传返回的 ETag 与本地计算的 md5 不一致
+type MD5MismatchError struct {
+	ClientMD5 string
+	ETag      string
+	RequestId string
+}
+
+func (e *MD5MismatchError) Error() string {
+	return fmt.Sprintf("md5 mismatch: client %s, etag %s (request id %s)", e.ClientMD5, e.ETag, e.RequestId)
+}
+
+// check_etag 比较普通上传的 ETag 与本地数据的 md5，
+// 使用 KMS 加密的文件 ETag 不是 md5，不做比较
+func (c Client) check_etag(data []byte, result ResponseHeader) error {
+	if !c.enable_md5 || len(result.ETag) == 0 || result.ServerSideEncryption == "KMS" {
+		return nil
+	}
+	sum := md5.Sum(data)
+	local := strings.ToUpper(hex.EncodeToString(sum[:]))
+	if local != strings.ToUpper(result.ETag) {
+		return &MD5MismatchError{local, result.ETag, result.RequestId}
+	}
+	return nil
+}
+
+// EnableMD5 设置上传时是否计算并发送 Content-MD5，开启后普通上传还会比较返回的 ETag，默认关闭
+func (c *Client) EnableMD5(enable bool) {
+	c.enable_md5 = enable
+}
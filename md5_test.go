@@ -0,0 +1,39 @@
+package oss
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+func TestContentMD5(t *testing.T) {
+	if content_md5([]byte("0123456789")) != "eB5eJF1ptWaXm4bijSPyxw==" {
+		t.Error("content md5 error")
+	}
+
+	client, _ := New("foo", "bar", "honglei123", "cn-shanghai")
+	client.SetClock(func() time.Time {
+		return time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	})
+	headers := client.AuthorizationHeader("PUT", types.NewCanonicalizedResource("/honglei123/a.txt"), map[string]string{
+		"Content-MD5":  "eB5eJF1ptWaXm4bijSPyxw==",
+		"Content-Type": "text/plain",
+	})
+	sign_str := "PUT\neB5eJF1ptWaXm4bijSPyxw==\ntext/plain\nFri, 01 Mar 2024 08:00:00 GMT\n/honglei123/a.txt"
+	if headers["Authorization"] != "OSS foo:"+types.NewSecret("bar").Encryption(sign_str) {
+		t.Error("signature should contain content md5")
+	}
+
+	client.EnableMD5(true)
+	var md5_err *MD5MismatchError
+	err := client.check_etag([]byte("0123456789"), ResponseHeader{ETag: "781E5E245D69B566979B86E28D23F2C7"})
+	if err != nil {
+		t.Error("etag should match md5")
+	}
+	err = client.check_etag([]byte("0123456789"), ResponseHeader{ETag: "781E5E245D69B566979B86E28D23F2C8"})
+	if !errors.As(err, &md5_err) {
+		t.Error("etag mismatch should return MD5MismatchError")
+	}
+}
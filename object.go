@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/tu6ge/oss-go/types"
 )
@@ -16,6 +18,7 @@ type Objects struct {
 	List      []Object
 	NextToken string
 	query     types.ObjectQuery
+	bucket    Bucket
 }
 
 func (objs Objects) NextList(client *Client) (Objects, error) {
@@ -23,7 +26,7 @@ func (objs Objects) NextList(client *Client) (Objects, error) {
 		return Objects{}, &NoFoundMoreObject{}
 	}
 	objs.query.Insert(types.QUERY_CONTINUATION_TOKEN, objs.NextToken)
-	return client.Bucket.ObjectQuery(objs.query).GetObjects(client)
+	return objs.bucket.ObjectQuery(objs.query).GetObjects(client)
 }
 
 type NoFoundMoreObject struct{}
@@ -35,8 +38,11 @@ func (n *NoFoundMoreObject) Error() string {
 type Object struct {
 	path         string
 	content      []byte
-	content_type string
+	headers      object_headers
 	copy_source  string
+	copy_headers object_headers
+	bucket       *Bucket
+	version_id   string
 	errors       error
 }
 
@@ -45,7 +51,26 @@ func (obj Object) String() string {
 }
 
 func NewObject(path string) Object {
-	return Object{path, nil, "", "", nil}
+	return Object{path, nil, nil, "", nil, nil, "", nil}
+}
+
+// Bucket 指定文件所在的 bucket，不设置时使用 client.Bucket
+func (obj Object) Bucket(bucket Bucket) Object {
+	obj.bucket = &bucket
+	return obj
+}
+
+// VersionId 指定 Head、Download、Delete、标签、访问权限等操作的文件版本
+func (obj Object) VersionId(id string) Object {
+	obj.version_id = id
+	return obj
+}
+
+func (obj Object) get_bucket(client *Client) Bucket {
+	if obj.bucket != nil {
+		return *obj.bucket
+	}
+	return client.Bucket
 }
 
 func (obj Object) ToUrl(bucket *Bucket) url.URL {
@@ -81,23 +106,71 @@ func (obj Object) FilePath(name string) Object {
 }
 
 func (obj Object) ContentType(con string) Object {
-	obj.content_type = con
+	obj.headers = obj.headers.with(HEADER_CONTENT_TYPE, con)
+	return obj
+}
+
+// Meta 设置用户自定义的元数据，即 x-oss-meta-* 请求头，key 不区分大小写
+func (obj Object) Meta(key, value string) Object {
+	obj.headers = obj.headers.meta(key, value)
+	return obj
+}
+
+func (obj Object) CacheControl(value string) Object {
+	obj.headers = obj.headers.with(HEADER_CACHE_CONTROL, value)
+	return obj
+}
+
+func (obj Object) ContentDisposition(value string) Object {
+	obj.headers = obj.headers.with(HEADER_CONTENT_DISPOSITION, value)
+	return obj
+}
+
+func (obj Object) ContentEncoding(value string) Object {
+	obj.headers = obj.headers.with(HEADER_CONTENT_ENCODING, value)
+	return obj
+}
+
+func (obj Object) ContentLanguage(value string) Object {
+	obj.headers = obj.headers.with(HEADER_CONTENT_LANGUAGE, value)
+	return obj
+}
+
+func (obj Object) Expires(t time.Time) Object {
+	obj.headers = obj.headers.with(HEADER_EXPIRES, t.UTC().Format(http.TimeFormat))
+	return obj
+}
+
+func (obj Object) StorageClass(class types.StorageClass) Object {
+	obj.headers = obj.headers.with(HEADER_STORAGE_CLASS, string(class))
 	return obj
 }
 
-func (obj Object) Upload(client *Client) error {
+func (obj Object) ACL(acl types.ACL) Object {
+	obj.headers = obj.headers.with(HEADER_OBJECT_ACL, string(acl))
+	return obj
+}
+
+// ForbidOverwrite 为 true 时，目标文件已存在则上传、复制失败
+func (obj Object) ForbidOverwrite(forbid bool) Object {
+	obj.headers = obj.headers.with(HEADER_FORBID_OVERWRITE, strconv.FormatBool(forbid))
+	return obj
+}
+
+func (obj Object) Upload(client *Client) (PutObjectResult, error) {
 	if obj.errors != nil {
-		return obj.errors
+		return PutObjectResult{}, obj.errors
 	}
 
-	bucket := client.Bucket
+	bucket := obj.get_bucket(client)
 	url := obj.ToUrl(&bucket)
 	method := "PUT"
 
 	resource := CanonicalizedResourceFromObject(&bucket, &obj)
 	headers := make(map[string]string)
-	if len(obj.content_type) > 0 {
-		headers["Content-Type"] = obj.content_type
+	obj.headers.apply(headers)
+	if client.enable_md5 {
+		headers["Content-MD5"] = content_md5(obj.content)
 	}
 	headers = client.AuthorizationHeader(method, resource, headers)
 
@@ -107,7 +180,7 @@ func (obj Object) Upload(client *Client) error {
 
 	req, err := http.NewRequest(method, url.String(), bytes.NewReader(obj.content))
 	if err != nil {
-		return err
+		return PutObjectResult{}, err
 	}
 
 	for k, v := range headers {
@@ -116,36 +189,65 @@ func (obj Object) Upload(client *Client) error {
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return err
+		return PutObjectResult{}, err
 	}
 
 	defer resp.Body.Close()
 
 	if http_status_ok(resp.StatusCode) {
-		return nil
+		result := PutObjectResult{new_response_header(resp.Header)}
+		if err := client.check_crc64(crc64_checksum(obj.content), resp.Header); err != nil {
+			return result, err
+		}
+		if err := client.check_etag(obj.content, result.ResponseHeader); err != nil {
+			return result, err
+		}
+		return result, nil
 	} else {
 		// 读取响应体
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return err
+			return PutObjectResult{}, err
 		}
 		body_string := string(body)
-		return parse_oss_response_error(body_string)
+		return PutObjectResult{}, client.response_error(resp, body_string)
 	}
 }
 
 func (obj Object) Download(client *Client) ([]byte, error) {
-	bucket := client.Bucket
+	result, err := obj.get(client, "")
+	return result.Content, err
+}
+
+// DownloadRange 下载文件 [start, end] 字节范围内的内容
+func (obj Object) DownloadRange(client *Client, start, end int64) ([]byte, error) {
+	result, err := obj.get(client, fmt.Sprintf("bytes=%d-%d", start, end))
+	return result.Content, err
+}
+
+// Get 下载文件内容，同时返回文件的元信息，
+// 例如可以通过 IsSymlink 判断是否通过软链接访问
+func (obj Object) Get(client *Client) (GetObjectResult, error) {
+	return obj.get(client, "")
+}
+
+func (obj Object) get(client *Client, byte_range string) (GetObjectResult, error) {
+	bucket := obj.get_bucket(client)
 	url := obj.ToUrl(&bucket)
 	method := "GET"
 
-	resource := CanonicalizedResourceFromObject(&bucket, &obj)
+	url.RawQuery = obj.version_query("")
+
+	resource := canonicalized_resource_version(&bucket, &obj, "")
 
 	headers := client.Authorization(method, resource)
+	if len(byte_range) > 0 {
+		headers["Range"] = byte_range
+	}
 
 	req, err := http.NewRequest(method, url.String(), nil)
 	if err != nil {
-		return nil, err
+		return GetObjectResult{}, err
 	}
 
 	for k, v := range headers {
@@ -154,48 +256,135 @@ func (obj Object) Download(client *Client) ([]byte, error) {
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, err
+		return GetObjectResult{}, err
 	}
 
 	defer resp.Body.Close()
 
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return GetObjectResult{}, err
 	}
 
 	if http_status_ok(resp.StatusCode) {
-		return data, nil
+		// 范围下载时响应头中的 crc 是整个文件的，不做校验；
+		// 使用 Content-Encoding: gzip 保存的文件会被自动解压，crc 对应的是压缩后的内容，也不做校验
+		if len(byte_range) == 0 && !resp.Uncompressed {
+			if err := client.check_crc64(crc64_checksum(data), resp.Header); err != nil {
+				return GetObjectResult{}, err
+			}
+		}
+		return GetObjectResult{parse_head_object_result(resp.Header, int64(len(data))), data}, nil
 	} else {
 		body_string := string(data)
-		return nil, parse_oss_response_error(body_string)
+		return GetObjectResult{}, client.response_error(resp, body_string)
+	}
+}
+
+// Head 获取文件的元信息，不返回文件内容
+func (obj Object) Head(client *Client) (HeadObjectResult, error) {
+	bucket := obj.get_bucket(client)
+	url := obj.ToUrl(&bucket)
+	method := "HEAD"
+
+	url.RawQuery = obj.version_query("")
+
+	resource := canonicalized_resource_version(&bucket, &obj, "")
+
+	headers := client.Authorization(method, resource)
+
+	req, err := http.NewRequest(method, url.String(), nil)
+	if err != nil {
+		return HeadObjectResult{}, err
+	}
+
+	for k, v := range headers {
+		req.Header.Add(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return HeadObjectResult{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if http_status_ok(resp.StatusCode) {
+		return parse_head_object_result(resp.Header, resp.ContentLength), nil
+	} else {
+		// HEAD 请求没有响应体，错误信息从响应头中获取
+		return HeadObjectResult{}, client.response_error(resp, "")
 	}
 }
 
+// CopySource 设置复制的源文件，格式为 /bucket/key，key 需要调用方自行编码，
+// 推荐使用 CopyFrom
 func (obj Object) CopySource(source string) Object {
 	obj.copy_source = source
 	return obj
 }
 
-func (obj Object) Copy(client *Client) error {
-	bucket := client.Bucket
+// CopyFrom 设置复制的源文件，源 bucket 需要与目标 bucket 在同一地域
+func (obj Object) CopyFrom(source CopySource) Object {
+	obj.copy_source = source.String()
+	return obj
+}
+
+// MetadataDirective 复制时元数据的处理方式，默认为 COPY，
+// 使用 REPLACE 时会使用本次设置的元数据和请求头
+func (obj Object) MetadataDirective(directive types.Directive) Object {
+	obj.copy_headers = obj.copy_headers.with(HEADER_METADATA_DIRECTIVE, string(directive))
+	return obj
+}
+
+// TaggingDirective 复制时标签的处理方式，默认为 COPY
+func (obj Object) TaggingDirective(directive types.Directive) Object {
+	obj.copy_headers = obj.copy_headers.with(HEADER_TAGGING_DIRECTIVE, string(directive))
+	return obj
+}
+
+// CopySourceIfMatch 源文件的 ETag 与 etag 一致时才复制
+func (obj Object) CopySourceIfMatch(etag string) Object {
+	obj.copy_headers = obj.copy_headers.with(HEADER_COPY_SOURCE_IF_MATCH, etag)
+	return obj
+}
+
+// CopySourceIfNoneMatch 源文件的 ETag 与 etag 不一致时才复制
+func (obj Object) CopySourceIfNoneMatch(etag string) Object {
+	obj.copy_headers = obj.copy_headers.with(HEADER_COPY_SOURCE_IF_NONE_MATCH, etag)
+	return obj
+}
+
+// CopySourceIfModifiedSince 源文件在 t 之后被修改过才复制
+func (obj Object) CopySourceIfModifiedSince(t time.Time) Object {
+	obj.copy_headers = obj.copy_headers.with(HEADER_COPY_SOURCE_IF_MODIFIED_SINCE, t.UTC().Format(http.TimeFormat))
+	return obj
+}
+
+// CopySourceIfUnmodifiedSince 源文件在 t 之后没有被修改过才复制
+func (obj Object) CopySourceIfUnmodifiedSince(t time.Time) Object {
+	obj.copy_headers = obj.copy_headers.with(HEADER_COPY_SOURCE_IF_UNMODIFIED_SINCE, t.UTC().Format(http.TimeFormat))
+	return obj
+}
+
+func (obj Object) Copy(client *Client) (CopyObjectResult, error) {
+	bucket := obj.get_bucket(client)
 	url := obj.ToUrl(&bucket)
 	method := "PUT"
 
 	resource := CanonicalizedResourceFromObject(&bucket, &obj)
 	headers := make(map[string]string)
 	if len(obj.copy_source) == 0 {
-		return errors.New("not found copy source")
-	}
-	headers["x-oss-copy-source"] = obj.copy_source
-	if len(obj.content_type) > 0 {
-		headers["Content-Type"] = obj.content_type
+		return CopyObjectResult{}, errors.New("not found copy source")
 	}
+	obj.headers.apply(headers)
+	obj.copy_headers.apply(headers)
+	headers[HEADER_COPY_SOURCE] = obj.copy_source
 	headers = client.AuthorizationHeader(method, resource, headers)
 
 	req, err := http.NewRequest(method, url.String(), nil)
 	if err != nil {
-		return err
+		return CopyObjectResult{}, err
 	}
 
 	for k, v := range headers {
@@ -204,35 +393,46 @@ func (obj Object) Copy(client *Client) error {
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return err
+		return CopyObjectResult{}, err
 	}
 
 	defer resp.Body.Close()
 
+	// 读取响应体
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return CopyObjectResult{}, err
+	}
+	body_string := string(body)
+
 	if http_status_ok(resp.StatusCode) {
-		return nil
+		return parse_copy_object_result(resp.Header, body_string), nil
 	} else {
-		// 读取响应体
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
-		body_string := string(body)
-		return parse_oss_response_error(body_string)
+		return CopyObjectResult{}, client.response_error(resp, body_string)
 	}
 }
 
-func (obj Object) Delete(client *Client) error {
-	bucket := client.Bucket
+// UpdateMeta 把文件复制到自身来修改元数据，会使用 REPLACE 方式，
+// 没有在本次设置的元数据和请求头都会被清除
+func (obj Object) UpdateMeta(client *Client) (CopyObjectResult, error) {
+	return obj.CopyFrom(NewCopySource(obj.get_bucket(client), obj.path)).
+		MetadataDirective(types.DIRECTIVE_REPLACE).
+		Copy(client)
+}
+
+func (obj Object) Delete(client *Client) (DeleteObjectResult, error) {
+	bucket := obj.get_bucket(client)
 	url := obj.ToUrl(&bucket)
 	method := "DELETE"
 
-	resource := CanonicalizedResourceFromObject(&bucket, &obj)
+	url.RawQuery = obj.version_query("")
+
+	resource := canonicalized_resource_version(&bucket, &obj, "")
 	headers := client.Authorization(method, resource)
 
 	req, err := http.NewRequest(method, url.String(), nil)
 	if err != nil {
-		return err
+		return DeleteObjectResult{}, err
 	}
 
 	for k, v := range headers {
@@ -241,22 +441,55 @@ func (obj Object) Delete(client *Client) error {
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return err
+		return DeleteObjectResult{}, err
 	}
 
 	defer resp.Body.Close()
 
 	if http_status_ok(resp.StatusCode) {
-		return nil
+		return parse_delete_object_result(resp.Header), nil
 	} else {
 		// 读取响应体
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return err
+			return DeleteObjectResult{}, err
 		}
 		body_string := string(body)
-		return parse_oss_response_error(body_string)
+		return DeleteObjectResult{}, client.response_error(resp, body_string)
+	}
+}
+
+// copy_source 生成 x-oss-copy-source 请求头的值，文件名需要经过 url 编码
+func copy_source(bucket, key string) string {
+	return fmt.Sprintf("/%s/%s", bucket, url.QueryEscape(key))
+}
+
+// version_query 生成带 versionId 的查询字符串，sub_resource 为 acl、tagging 等子资源
+func (obj Object) version_query(sub_resource string) string {
+	query := sub_resource
+	if len(obj.version_id) > 0 {
+		if len(query) > 0 {
+			query += "&"
+		}
+		query += "versionId=" + url.QueryEscape(obj.version_id)
+	}
+	return query
+}
+
+// canonicalized_resource_version 签名使用的资源，子资源按字典序排列，versionId 总是在最后
+func canonicalized_resource_version(bucket *Bucket, object *Object, sub_resource string) types.CanonicalizedResource {
+	resource := fmt.Sprintf("/%s/%s", bucket.name, object.path)
+	query := sub_resource
+	if len(object.version_id) > 0 {
+		if len(query) > 0 {
+			query += "&"
+		}
+		query += "versionId=" + object.version_id
+	}
+	if len(query) > 0 {
+		resource += "?" + query
 	}
+	return types.NewCanonicalizedResource(resource)
 }
 
 func CanonicalizedResourceFromObject(bucket *Bucket, object *Object) types.CanonicalizedResource {
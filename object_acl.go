@@ -0,0 +1,37 @@
+package oss
+
+import (
+	"encoding/xml"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+type Owner struct {
+	ID          string
+	DisplayName string
+}
+
+// AccessControlPolicy 文件或 bucket 的访问权限
+type AccessControlPolicy struct {
+	Owner Owner
+	ACL   types.ACL `xml:"AccessControlList>Grant"`
+}
+
+// PutACL 修改文件的访问权限，types.ACL_DEFAULT 表示继承 bucket 的权限
+func (obj Object) PutACL(client *Client, acl types.ACL) error {
+	headers := map[string]string{
+		HEADER_OBJECT_ACL: string(acl),
+	}
+	_, err := obj.sub_resource_request(client, "PUT", "acl", headers, nil)
+	return err
+}
+
+func (obj Object) GetACL(client *Client) (AccessControlPolicy, error) {
+	data, err := obj.sub_resource_request(client, "GET", "acl", make(map[string]string), nil)
+	if err != nil {
+		return AccessControlPolicy{}, err
+	}
+	var result AccessControlPolicy
+	err = xml.Unmarshal(data, &result)
+	return result, err
+}
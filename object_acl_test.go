@@ -0,0 +1,29 @@
+package oss
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+func TestParseAccessControlPolicy(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<AccessControlPolicy>
+  <Owner>
+    <ID>0022012****</ID>
+    <DisplayName>user_example</DisplayName>
+  </Owner>
+  <AccessControlList>
+    <Grant>public-read</Grant>
+  </AccessControlList>
+</AccessControlPolicy>`
+
+	var policy AccessControlPolicy
+	if err := xml.Unmarshal([]byte(data), &policy); err != nil {
+		t.Fatal(err)
+	}
+	if policy.ACL != types.ACL_PUBLIC_READ || policy.Owner.DisplayName != "user_example" {
+		t.Error("parse access control policy error")
+	}
+}
@@ -0,0 +1,191 @@
+package oss
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+// AppendObject 追加上传，每次追加后记录下一次追加的位置和整个文件的 crc64
+type AppendObject struct {
+	path     string
+	position int64
+	crc      uint64
+	// 从指定位置继续追加时，不知道已有内容的 crc
+	has_crc bool
+	headers object_headers
+	bucket  *Bucket
+}
+
+func NewAppendObject(path string) AppendObject {
+	return AppendObject{path, 0, 0, true, nil, nil}
+}
+
+// Bucket 指定文件所在的 bucket，不设置时使用 client.Bucket
+func (a AppendObject) Bucket(bucket Bucket) AppendObject {
+	a.bucket = &bucket
+	return a
+}
+
+func (a AppendObject) get_bucket(client *Client) Bucket {
+	if a.bucket != nil {
+		return *a.bucket
+	}
+	return client.Bucket
+}
+
+// Position 从已有文件的 position 处继续追加，一般为文件的长度
+func (a AppendObject) Position(position int64) AppendObject {
+	a.position = position
+	a.crc = 0
+	a.has_crc = position == 0
+	return a
+}
+
+// ContentType、Meta、StorageClass、ACL 只在第一次追加（position 为 0）时生效
+func (a AppendObject) ContentType(value string) AppendObject {
+	a.headers = a.headers.with(HEADER_CONTENT_TYPE, value)
+	return a
+}
+
+func (a AppendObject) Meta(key, value string) AppendObject {
+	a.headers = a.headers.meta(key, value)
+	return a
+}
+
+func (a AppendObject) StorageClass(class types.StorageClass) AppendObject {
+	a.headers = a.headers.with(HEADER_STORAGE_CLASS, string(class))
+	return a
+}
+
+func (a AppendObject) ACL(acl types.ACL) AppendObject {
+	a.headers = a.headers.with(HEADER_OBJECT_ACL, string(acl))
+	return a
+}
+
+// NextPosition 下一次追加的位置
+func (a AppendObject) NextPosition() int64 {
+	return a.position
+}
+
+func (a AppendObject) ToUrl(bucket *Bucket) url.URL {
+	url := bucket.ToUrl()
+	url.Path = a.path
+	return url
+}
+
+type AppendObjectResult struct {
+	ResponseHeader
+	NextPosition int64
+}
+
+// Append 把 data 追加到文件末尾。位置与文件长度不一致时返回 ErrPositionNotEqualLength，
+// 可以从错误的 Header 中读取 x-oss-next-append-position 后使用 Position 重新设置
+func (a *AppendObject) Append(client *Client, data []byte) (AppendObjectResult, error) {
+	bucket := a.get_bucket(client)
+	url := a.ToUrl(&bucket)
+	url.RawQuery = fmt.Sprintf("append&position=%d", a.position)
+	method := "POST"
+
+	resource := types.NewCanonicalizedResource(fmt.Sprintf("/%s/%s?append&position=%d", bucket.name, a.path, a.position))
+
+	headers := map[string]string{
+		"Content-Length": strconv.Itoa(len(data)),
+	}
+	if a.position == 0 {
+		a.headers.apply(headers)
+	}
+	if client.enable_md5 {
+		headers["Content-MD5"] = content_md5(data)
+	}
+	headers = client.AuthorizationHeader(method, resource, headers)
+
+	req, err := http.NewRequest(method, url.String(), bytes.NewReader(data))
+	if err != nil {
+		return AppendObjectResult{}, err
+	}
+
+	for k, v := range headers {
+		req.Header.Add(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return AppendObjectResult{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if !http_status_ok(resp.StatusCode) {
+		// 读取响应体
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return AppendObjectResult{}, err
+		}
+		return AppendObjectResult{}, client.response_error(resp, string(body))
+	}
+
+	result := AppendObjectResult{ResponseHeader: new_response_header(resp.Header)}
+	result.NextPosition, err = strconv.ParseInt(resp.Header.Get("x-oss-next-append-position"), 10, 64)
+	if err != nil {
+		return result, err
+	}
+	a.position = result.NextPosition
+
+	if a.has_crc {
+		a.crc = crc64_combine(a.crc, crc64_checksum(data), uint64(len(data)))
+		if err := client.check_crc64(a.crc, resp.Header); err != nil {
+			return result, err
+		}
+	} else if len(resp.Header.Get("x-oss-hash-crc64ecma")) > 0 {
+		// 继续追加时以服务端返回的 crc 为起点
+		a.crc = result.HashCRC64
+		a.has_crc = true
+	}
+
+	return result, nil
+}
+
+// Writer 返回一个 io.Writer，写入的数据先缓存起来，
+// 达到 size 字节后合并为一次追加；使用完后需要调用 Close 写入剩余数据
+func (a *AppendObject) Writer(client *Client, size int) *AppendWriter {
+	return &AppendWriter{a, client, nil, size}
+}
+
+type AppendWriter struct {
+	object *AppendObject
+	client *Client
+	buf    []byte
+	size   int
+}
+
+func (w *AppendWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= w.size {
+		if err := w.Flush(); err != nil {
+			return len(p), err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush 把缓存的数据追加到文件中，失败时数据保留在缓存中
+func (w *AppendWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	if _, err := w.object.Append(w.client, w.buf); err != nil {
+		return err
+	}
+	w.buf = w.buf[:0]
+	return nil
+}
+
+func (w *AppendWriter) Close() error {
+	return w.Flush()
+}
@@ -0,0 +1,62 @@
+package oss
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestAppendWriter(t *testing.T) {
+	var content []byte
+	var positions []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.Method != "POST" || !query.Has("append") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if query.Get("position") != strconv.Itoa(len(content)) {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, "<Error><Code>PositionNotEqualToLength</Code></Error>")
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		content = append(content, body...)
+		positions = append(positions, query.Get("position"))
+		w.Header().Set("x-oss-next-append-position", strconv.Itoa(len(content)))
+		w.Header().Set("x-oss-hash-crc64ecma", strconv.FormatUint(crc64_checksum(content), 10))
+	}))
+	defer server.Close()
+
+	client, _ := New("foo", "bar", "honglei123", "cn-shanghai")
+	client.SetBucketDomain(server.URL)
+
+	object := NewAppendObject("app.log").ContentType("text/plain")
+	writer := object.Writer(&client, 10)
+	for i := range 5 {
+		fmt.Fprintf(writer, "line %d\n", i)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != "line 0\nline 1\nline 2\nline 3\nline 4\n" || object.NextPosition() != 35 {
+		t.Error("append content error:", string(content))
+	}
+	if fmt.Sprint(positions) != "[0 14 28]" {
+		t.Error("append positions error:", positions)
+	}
+
+	// 从已有文件的末尾继续追加，第一次追加后使用服务端的 crc
+	resumed := NewAppendObject("app.log").Position(35)
+	if _, err := resumed.Append(&client, []byte("line 5\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := resumed.Append(&client, []byte("line 6\n")); err != nil {
+		t.Fatal(err)
+	}
+}
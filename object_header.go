@@ -0,0 +1,47 @@
+package oss
+
+import (
+	"maps"
+	"strings"
+)
+
+const (
+	HEADER_CONTENT_TYPE        = "Content-Type"
+	HEADER_CACHE_CONTROL       = "Cache-Control"
+	HEADER_CONTENT_DISPOSITION = "Content-Disposition"
+	HEADER_CONTENT_ENCODING    = "Content-Encoding"
+	HEADER_CONTENT_LANGUAGE    = "Content-Language"
+	HEADER_EXPIRES             = "Expires"
+	HEADER_META_PREFIX         = "x-oss-meta-"
+	HEADER_STORAGE_CLASS       = "x-oss-storage-class"
+	HEADER_OBJECT_ACL          = "x-oss-object-acl"
+	HEADER_FORBID_OVERWRITE    = "x-oss-forbid-overwrite"
+
+	HEADER_COPY_SOURCE                     = "x-oss-copy-source"
+	HEADER_COPY_SOURCE_RANGE               = "x-oss-copy-source-range"
+	HEADER_METADATA_DIRECTIVE              = "x-oss-metadata-directive"
+	HEADER_TAGGING_DIRECTIVE               = "x-oss-tagging-directive"
+	HEADER_COPY_SOURCE_IF_MATCH            = "x-oss-copy-source-if-match"
+	HEADER_COPY_SOURCE_IF_NONE_MATCH       = "x-oss-copy-source-if-none-match"
+	HEADER_COPY_SOURCE_IF_MODIFIED_SINCE   = "x-oss-copy-source-if-modified-since"
+	HEADER_COPY_SOURCE_IF_UNMODIFIED_SINCE = "x-oss-copy-source-if-unmodified-since"
+)
+
+// object_headers 上传、复制文件时附带的请求头，
+// 修改时总是返回新的副本，避免多个 Object 之间共享同一个 map
+type object_headers map[string]string
+
+func (h object_headers) with(key, value string) object_headers {
+	headers := make(object_headers, len(h)+1)
+	maps.Copy(headers, h)
+	headers[key] = value
+	return headers
+}
+
+func (h object_headers) meta(key, value string) object_headers {
+	return h.with(HEADER_META_PREFIX+strings.ToLower(key), value)
+}
+
+func (h object_headers) apply(headers map[string]string) {
+	maps.Copy(headers, h)
+}
@@ -0,0 +1,46 @@
+package oss
+
+import (
+	"testing"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+func TestObjectHeaders(t *testing.T) {
+	base := NewObject("a.txt").ContentType("text/plain")
+	obj1 := base.Meta("Author", "foo").StorageClass(types.STORAGE_CLASS_IA)
+	obj2 := base.ACL(types.ACL_PRIVATE).ForbidOverwrite(true)
+
+	if len(base.headers) != 1 {
+		t.Error("builder should not modify the original object")
+	}
+	if obj1.headers["x-oss-meta-author"] != "foo" || obj1.headers["x-oss-storage-class"] != "IA" {
+		t.Error("object meta headers error")
+	}
+	if _, ok := obj2.headers["x-oss-meta-author"]; ok {
+		t.Error("objects should not share headers")
+	}
+
+	headers := make(map[string]string)
+	obj2.headers.apply(headers)
+	if to_oss_header(headers) != "x-oss-forbid-overwrite:true\nx-oss-object-acl:private\n" {
+		t.Error("oss header string error")
+	}
+}
+
+func TestCopyHeaders(t *testing.T) {
+	obj := NewObject("b.txt").
+		CopySource(copy_source("honglei123", "dir/a b.txt")).
+		MetadataDirective(types.DIRECTIVE_REPLACE).
+		CopySourceIfMatch("abc")
+
+	if obj.copy_source != "/honglei123/dir%2Fa+b.txt" {
+		t.Error("copy source error:", obj.copy_source)
+	}
+	if obj.copy_headers["x-oss-metadata-directive"] != "REPLACE" || obj.copy_headers["x-oss-copy-source-if-match"] != "abc" {
+		t.Error("copy headers error")
+	}
+	if len(obj.headers) != 0 {
+		t.Error("copy headers should not be sent on upload")
+	}
+}
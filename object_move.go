@@ -0,0 +1,154 @@
+package oss
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+// Rename 在同一个 bucket 中重命名文件
+func (obj Object) Rename(client *Client, dst string) (CopyObjectResult, error) {
+	return obj.Move(client, NewObject(dst).Bucket(obj.get_bucket(client)))
+}
+
+// Move 把文件移动到 dst，dst 可以在同一地域的其他 bucket 中。
+// 先复制文件（大文件使用分片复制）并保留元数据，校验目标文件后再删除源文件。
+// 使用分片复制的大文件不会保留文件的 ACL，目标文件使用 bucket 的 ACL
+func (obj Object) Move(client *Client, dst Object) (CopyObjectResult, error) {
+	src_bucket := obj.get_bucket(client)
+	if src_bucket.name == dst.get_bucket(client).name && obj.path == dst.path {
+		// 复制到自身后再删除源文件会丢失文件
+		return CopyObjectResult{}, errors.New("source and destination are the same object")
+	}
+
+	source, err := obj.Head(client)
+	if err != nil {
+		return CopyObjectResult{}, err
+	}
+
+	result, err := NewPartsCopy(dst.path, NewCopySource(src_bucket, obj.path)).
+		Bucket(dst.get_bucket(client)).
+		Copy(client)
+	if err != nil {
+		return result, err
+	}
+
+	target, err := dst.Head(client)
+	if err != nil {
+		return result, err
+	}
+	if err := verify_copy(source, target); err != nil {
+		return result, err
+	}
+
+	_, err = obj.Delete(client)
+	return result, err
+}
+
+// verify_copy 校验复制后的文件与源文件一致：优先比较 crc64，
+// 没有 crc64 时比较大小，源文件是普通上传的文件时还会比较 ETag
+func verify_copy(source, target HeadObjectResult) error {
+	if source.ContentLength != target.ContentLength {
+		return fmt.Errorf("copy verify failed: size %d != %d", source.ContentLength, target.ContentLength)
+	}
+
+	source_crc := source.Header.Get("x-oss-hash-crc64ecma")
+	target_crc := target.Header.Get("x-oss-hash-crc64ecma")
+	if len(source_crc) > 0 && len(target_crc) > 0 {
+		if source.HashCRC64 != target.HashCRC64 {
+			return &CRCMismatchError{source.HashCRC64, target.HashCRC64, target.RequestId}
+		}
+		return nil
+	}
+
+	if source.ObjectType == "Normal" && source.ContentLength <= COPY_THRESHOLD && source.ETag != target.ETag {
+		return fmt.Errorf("copy verify failed: etag %s != %s", source.ETag, target.ETag)
+	}
+	return nil
+}
+
+type MoveOptions struct {
+	// 目标 bucket，为空时移动到同一个 bucket
+	Bucket *Bucket
+	// 同时移动的文件数量，默认为 3
+	Parallel int
+}
+
+type MoveError struct {
+	Key string
+	Err error
+}
+
+func (e MoveError) Error() string {
+	return fmt.Sprintf("move %s: %s", e.Key, e.Err)
+}
+
+func (e MoveError) Unwrap() error {
+	return e.Err
+}
+
+// MoveReport 按前缀移动文件的结果，Moved 为移动成功的源文件
+type MoveReport struct {
+	Moved  []string
+	Failed []MoveError
+}
+
+// MovePrefix 把 prefix 下的所有文件移动到 dst_prefix 下，
+// 单个文件移动失败不会中断，失败的文件记录在 MoveReport.Failed 中；
+// 只有获取文件列表失败时才返回 error
+func (b Bucket) MovePrefix(client *Client, prefix, dst_prefix string, opts MoveOptions) (MoveReport, error) {
+	dst_bucket := b
+	if opts.Bucket != nil {
+		dst_bucket = *opts.Bucket
+	}
+	if dst_bucket.name == b.name && strings.HasPrefix(dst_prefix, prefix) {
+		return MoveReport{}, errors.New("destination prefix can not be inside the source prefix")
+	}
+	parallel := opts.Parallel
+	if parallel < 1 {
+		parallel = 3
+	}
+
+	var report MoveReport
+	var mu sync.Mutex
+
+	query := types.NewObjectQuery()
+	query.Insert(types.QUERY_PREFIX, prefix)
+	objects, err := b.ObjectQuery(query).GetObjects(client)
+
+	for err == nil {
+		var wg sync.WaitGroup
+		limit := make(chan struct{}, parallel)
+		for _, obj := range objects.List {
+			dst := NewObject(dst_prefix + strings.TrimPrefix(obj.path, prefix)).Bucket(dst_bucket)
+
+			wg.Add(1)
+			limit <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-limit }()
+
+				_, err := obj.Move(client, dst)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					report.Failed = append(report.Failed, MoveError{obj.path, err})
+				} else {
+					report.Moved = append(report.Moved, obj.path)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(objects.NextToken) == 0 {
+			return report, nil
+		}
+		objects, err = objects.NextList(client)
+	}
+
+	return report, err
+}
@@ -0,0 +1,62 @@
+package oss
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVerifyCopy(t *testing.T) {
+	header := func(crc string) http.Header {
+		h := http.Header{}
+		if len(crc) > 0 {
+			h.Set("x-oss-hash-crc64ecma", crc)
+		}
+		return h
+	}
+	source := HeadObjectResult{ContentLength: 10, ObjectType: "Normal", Header: header("1")}
+	source.HashCRC64 = 1
+	source.ETag = "A"
+
+	target := HeadObjectResult{ContentLength: 10, ObjectType: "Normal", Header: header("1")}
+	target.HashCRC64 = 1
+	target.ETag = "A"
+	if verify_copy(source, target) != nil {
+		t.Error("same object should pass verify")
+	}
+
+	target.HashCRC64 = 2
+	var crc_err *CRCMismatchError
+	if !errors.As(verify_copy(source, target), &crc_err) {
+		t.Error("crc mismatch should fail verify")
+	}
+
+	source.Header = header("")
+	target.ETag = "B"
+	if verify_copy(source, target) == nil {
+		t.Error("etag mismatch should fail verify")
+	}
+
+	target.ContentLength = 11
+	if verify_copy(source, target) == nil {
+		t.Error("size mismatch should fail verify")
+	}
+}
+
+func TestMoveToSelf(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("move to self should not send request:", r.Method, r.URL)
+	}))
+	defer server.Close()
+
+	client, _ := New("foo", "bar", "honglei123", "cn-shanghai")
+	client.SetBucketDomain(server.URL)
+
+	if _, err := NewObject("a.txt").Rename(&client, "a.txt"); err == nil {
+		t.Error("rename to self should fail")
+	}
+	if _, err := NewObject("a.txt").Move(&client, NewObject("a.txt").Bucket(client.Bucket)); err == nil {
+		t.Error("move to self should fail")
+	}
+}
@@ -0,0 +1,278 @@
+package oss
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+// oss 分片的最小大小，最后一个分片除外
+const MIN_PART_SIZE int64 = 100 * 1024
+
+// PartsCompose 把多个文件按顺序拼接成一个文件，基于分片上传实现：
+// 大文件使用 UploadPartCopy 在服务端复制，不足最小分片大小的文件
+// 需要下载下来合并后再使用 UploadPart 上传
+type PartsCompose struct {
+	path      string
+	sources   []CopySource
+	part_size int64
+	parallel  int
+	headers   object_headers
+	bucket    *Bucket
+}
+
+func NewPartsCompose(path string, sources ...CopySource) PartsCompose {
+	return PartsCompose{path, sources, COPY_PART_SIZE, 3, nil, nil}
+}
+
+// Bucket 指定目标文件所在的 bucket，不设置时使用 client.Bucket
+func (m PartsCompose) Bucket(bucket Bucket) PartsCompose {
+	m.bucket = &bucket
+	return m
+}
+
+func (m PartsCompose) PartSize(size int64) PartsCompose {
+	m.part_size = size
+	return m
+}
+
+// Parallel 同时上传的分片数量
+func (m PartsCompose) Parallel(n int) PartsCompose {
+	m.parallel = n
+	return m
+}
+
+// ContentType、Meta、StorageClass 设置目标文件的元数据，
+// 都不设置时使用第一个源文件的元数据
+func (m PartsCompose) ContentType(value string) PartsCompose {
+	m.headers = m.headers.with(HEADER_CONTENT_TYPE, value)
+	return m
+}
+
+func (m PartsCompose) Meta(key, value string) PartsCompose {
+	m.headers = m.headers.meta(key, value)
+	return m
+}
+
+func (m PartsCompose) StorageClass(class types.StorageClass) PartsCompose {
+	m.headers = m.headers.with(HEADER_STORAGE_CLASS, string(class))
+	return m
+}
+
+// compose_piece 源文件中 [start, end] 字节范围
+type compose_piece struct {
+	source CopySource
+	start  int64
+	end    int64
+}
+
+func (p compose_piece) size() int64 {
+	return p.end - p.start + 1
+}
+
+// compose_part 目标文件的一个分片，copy 为 true 时使用 UploadPartCopy 复制唯一的 piece，
+// 否则下载所有 piece 拼接后上传
+type compose_part struct {
+	copy   bool
+	pieces []compose_piece
+}
+
+func (m PartsCompose) Compose(client *Client) (CompleteMultipartUploadResult, error) {
+	if len(m.sources) == 0 {
+		return CompleteMultipartUploadResult{}, errors.New("not found compose source")
+	}
+	if m.part_size < MIN_PART_SIZE {
+		return CompleteMultipartUploadResult{}, errors.New("part size not less than 100k")
+	}
+	if m.parallel < 1 {
+		m.parallel = 1
+	}
+
+	heads := make([]HeadObjectResult, len(m.sources))
+	sizes := make([]int64, len(m.sources))
+	for i, source := range m.sources {
+		head, err := source.object().Head(client)
+		if err != nil {
+			return CompleteMultipartUploadResult{}, err
+		}
+		heads[i] = head
+		sizes[i] = head.ContentLength
+	}
+
+	headers := m.headers
+	if len(headers) == 0 {
+		headers = source_headers(heads[0].Header)
+	}
+
+	plan, err := compose_plan(m.sources, sizes, m.part_size)
+	if err != nil {
+		return CompleteMultipartUploadResult{}, err
+	}
+	if len(plan) == 0 {
+		// 所有源文件都是空文件
+		obj := NewObject(m.path)
+		obj.bucket = m.bucket
+		obj.headers = headers
+		result, err := obj.Upload(client)
+		return CompleteMultipartUploadResult{ResponseHeader: result.ResponseHeader}, err
+	}
+
+	parts := NewPartsUpload(m.path)
+	parts.bucket = m.bucket
+	parts.headers = headers
+	if err := parts.InitMulit(client); err != nil {
+		return CompleteMultipartUploadResult{}, err
+	}
+
+	parts.etag_list, err = m.upload_parts(&parts, plan, client)
+	if err != nil {
+		parts.Abort(client)
+		return CompleteMultipartUploadResult{}, err
+	}
+
+	result, err := parts.Complete(client)
+	if err != nil {
+		// 合并失败时取消上传，避免已上传的分片继续占用存储
+		parts.Abort(client)
+		return result, err
+	}
+
+	if crc, ok := compose_crc64(heads); ok && !client.disable_crc && result.HashCRC64 != 0 && crc != result.HashCRC64 {
+		return result, &CRCMismatchError{crc, result.HashCRC64, result.RequestId}
+	}
+	return result, nil
+}
+
+func (m PartsCompose) upload_parts(parts *PartsUpload, plan []compose_part, client *Client) ([]etag_struct, error) {
+	etags := make([]etag_struct, len(plan))
+	errs := make([]error, len(plan))
+
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, m.parallel)
+	for i, part := range plan {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-limit }()
+
+			if part.copy {
+				piece := part.pieces[0]
+				etags[i], errs[i] = parts.upload_part_copy(i+1, piece.source, piece.start, piece.end, client)
+				return
+			}
+
+			var con []byte
+			for _, piece := range part.pieces {
+				data, err := piece.source.object().DownloadRange(client, piece.start, piece.end)
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				con = append(con, data...)
+			}
+			etags[i], errs[i] = parts.upload_part(i+1, con, client)
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return etags, nil
+}
+
+// compose_plan 按顺序把源文件划分成分片，保证除最后一个分片外都不小于 MIN_PART_SIZE。
+// 总大小超过 part_size*MAX_PART_COUNT 时增大分片大小；每个不小于 MIN_PART_SIZE 的源文件
+// 至少占一个分片，分片数量仍然超过 MAX_PART_COUNT 时返回 error
+func compose_plan(sources []CopySource, sizes []int64, part_size int64) ([]compose_part, error) {
+	var total int64
+	for _, size := range sizes {
+		total += size
+	}
+	if total > part_size*MAX_PART_COUNT {
+		part_size = (total + MAX_PART_COUNT - 1) / MAX_PART_COUNT
+	}
+
+	var plan []compose_part
+	var pending []compose_piece
+	var pending_size int64
+
+	flush := func() {
+		if len(pending) > 0 {
+			plan = append(plan, compose_part{false, pending})
+			pending = nil
+			pending_size = 0
+		}
+	}
+
+	for i, source := range sources {
+		size := sizes[i]
+		if size == 0 {
+			continue
+		}
+
+		start := int64(0)
+		if pending_size > 0 {
+			need := MIN_PART_SIZE - pending_size
+			if size-need < MIN_PART_SIZE {
+				// 剩余部分不够一个分片，整个文件都需要下载
+				pending = append(pending, compose_piece{source, 0, size - 1})
+				pending_size += size
+				if pending_size >= MIN_PART_SIZE {
+					flush()
+				}
+				continue
+			}
+			// 取文件开头的一部分补足等待上传的分片，剩余部分在服务端复制
+			pending = append(pending, compose_piece{source, 0, need - 1})
+			flush()
+			start = need
+		} else if size < MIN_PART_SIZE {
+			pending = append(pending, compose_piece{source, 0, size - 1})
+			pending_size = size
+			continue
+		}
+
+		plan = append(plan, split_copy_parts(source, start, size, part_size)...)
+	}
+	flush()
+
+	if int64(len(plan)) > MAX_PART_COUNT {
+		return nil, fmt.Errorf("compose needs %d parts, more than %d", len(plan), MAX_PART_COUNT)
+	}
+	return plan, nil
+}
+
+// split_copy_parts 把 [start, size) 划分成复制的分片，最后一个分片不足最小大小时合并到前一个
+func split_copy_parts(source CopySource, start, size, part_size int64) []compose_part {
+	var parts []compose_part
+	for offset := start; offset < size; offset += part_size {
+		end := min(offset+part_size, size) - 1
+		if size-end-1 < MIN_PART_SIZE {
+			end = size - 1
+		}
+		parts = append(parts, compose_part{true, []compose_piece{{source, offset, end}}})
+		if end == size-1 {
+			break
+		}
+	}
+	return parts
+}
+
+// compose_crc64 按顺序合并所有源文件的 crc，有源文件没有 crc 时返回 false
+func compose_crc64(heads []HeadObjectResult) (uint64, bool) {
+	var crc uint64
+	for _, head := range heads {
+		if len(head.Header.Get("x-oss-hash-crc64ecma")) == 0 {
+			if head.ContentLength == 0 {
+				continue
+			}
+			return 0, false
+		}
+		crc = crc64_combine(crc, head.HashCRC64, uint64(head.ContentLength))
+	}
+	return crc, true
+}
@@ -0,0 +1,68 @@
+package oss
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestComposePlan(t *testing.T) {
+	bucket, _ := NewBucket("honglei123", "cn-shanghai")
+	var sources []CopySource
+	for i := range 5 {
+		sources = append(sources, NewCopySource(bucket, fmt.Sprintf("%d.log", i)))
+	}
+	k := int64(1024)
+	sizes := []int64{10 * k, 300 * k, 50 * k, 0, 120 * k}
+
+	plan, err := compose_plan(sources, sizes, 200*k)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result []string
+	for _, part := range plan {
+		str := fmt.Sprintf("copy=%v", part.copy)
+		for _, piece := range part.pieces {
+			str += fmt.Sprintf(" %s:%d-%d", piece.source.key, piece.start, piece.end)
+		}
+		result = append(result, str)
+	}
+
+	expected := []string{
+		// 第一个文件太小，从第二个文件取 90k 补足一个分片
+		"copy=false 0.log:0-10239 1.log:0-92159",
+		"copy=true 1.log:92160-307199",
+		// 第三个文件后面剩余的部分不足一个分片，整个下载
+		"copy=false 2.log:0-51199 4.log:0-122879",
+	}
+	if fmt.Sprint(result) != fmt.Sprint(expected) {
+		t.Errorf("compose plan error: %q", result)
+	}
+}
+
+func TestComposePlanPartCount(t *testing.T) {
+	bucket, _ := NewBucket("honglei123", "cn-shanghai")
+	m := int64(1024 * 1024)
+
+	// 每个 1MB 的日志分片各占一个分片，超过分片数量限制
+	count := int(MAX_PART_COUNT) + 1
+	sources := make([]CopySource, count)
+	sizes := make([]int64, count)
+	for i := range count {
+		sources[i] = NewCopySource(bucket, fmt.Sprintf("%d.log", i))
+		sizes[i] = m
+	}
+	if _, err := compose_plan(sources, sizes, COPY_PART_SIZE); err == nil {
+		t.Error("compose plan should fail when parts exceed MAX_PART_COUNT")
+	}
+	if _, err := compose_plan(sources[:MAX_PART_COUNT], sizes[:MAX_PART_COUNT], COPY_PART_SIZE); err != nil {
+		t.Error("compose plan with MAX_PART_COUNT parts should pass:", err)
+	}
+
+	// 大文件按总大小增大分片
+	big := []CopySource{NewCopySource(bucket, "big.log")}
+	plan, err := compose_plan(big, []int64{1024 * 1024 * m}, COPY_PART_SIZE)
+	if err != nil || int64(len(plan)) > MAX_PART_COUNT {
+		t.Error("compose plan should grow part size for big source:", len(plan), err)
+	}
+}
@@ -0,0 +1,198 @@
+package oss
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+const (
+	// 超过这个大小的文件使用分片复制，oss 的普通复制最大支持 1GB
+	COPY_THRESHOLD int64 = 1024 * 1024 * 1024
+	// 分片复制默认的分片大小
+	COPY_PART_SIZE int64 = 64 * 1024 * 1024
+	// oss 单次分片上传最多 10000 个分片
+	MAX_PART_COUNT int64 = 10000
+)
+
+// PartsCopy 复制大文件，小于阈值的文件使用普通复制，
+// 否则使用 UploadPartCopy 并发复制每个分片
+type PartsCopy struct {
+	path      string
+	source    CopySource
+	part_size int64
+	parallel  int
+	threshold int64
+	headers   object_headers
+	bucket    *Bucket
+}
+
+func NewPartsCopy(path string, source CopySource) PartsCopy {
+	return PartsCopy{path, source, COPY_PART_SIZE, 3, COPY_THRESHOLD, nil, nil}
+}
+
+// Bucket 指定目标文件所在的 bucket，不设置时使用 client.Bucket
+func (m PartsCopy) Bucket(bucket Bucket) PartsCopy {
+	m.bucket = &bucket
+	return m
+}
+
+func (m PartsCopy) PartSize(size int64) PartsCopy {
+	m.part_size = size
+	return m
+}
+
+// Parallel 同时复制的分片数量
+func (m PartsCopy) Parallel(n int) PartsCopy {
+	m.parallel = n
+	return m
+}
+
+// Threshold 文件大小超过 size 时使用分片复制
+func (m PartsCopy) Threshold(size int64) PartsCopy {
+	m.threshold = size
+	return m
+}
+
+// ContentType、Meta、StorageClass 设置目标文件的元数据，
+// 设置任意一项后不再保留源文件的元数据
+func (m PartsCopy) ContentType(value string) PartsCopy {
+	m.headers = m.headers.with(HEADER_CONTENT_TYPE, value)
+	return m
+}
+
+func (m PartsCopy) Meta(key, value string) PartsCopy {
+	m.headers = m.headers.meta(key, value)
+	return m
+}
+
+func (m PartsCopy) StorageClass(class types.StorageClass) PartsCopy {
+	m.headers = m.headers.with(HEADER_STORAGE_CLASS, string(class))
+	return m
+}
+
+func (m PartsCopy) Copy(client *Client) (CopyObjectResult, error) {
+	if m.part_size < 1024*100 {
+		return CopyObjectResult{}, errors.New("part size not less than 100k")
+	}
+	if m.parallel < 1 {
+		m.parallel = 1
+	}
+
+	head, err := m.source.object().Head(client)
+	if err != nil {
+		return CopyObjectResult{}, err
+	}
+
+	if head.ContentLength <= m.threshold {
+		return m.single_copy(client)
+	}
+
+	headers := m.headers
+	if len(headers) == 0 {
+		headers = source_headers(head.Header)
+	}
+
+	parts := NewPartsUpload(m.path)
+	parts.bucket = m.bucket
+	parts.headers = headers
+	if err := parts.InitMulit(client); err != nil {
+		return CopyObjectResult{}, err
+	}
+
+	parts.etag_list, err = m.copy_parts(&parts, head.ContentLength, client)
+	if err != nil {
+		parts.Abort(client)
+		return CopyObjectResult{}, err
+	}
+
+	complete, err := parts.Complete(client)
+	if err != nil {
+		// 合并失败时取消上传，避免已复制的分片继续占用存储
+		parts.Abort(client)
+		return CopyObjectResult{}, err
+	}
+
+	result := CopyObjectResult{
+		ResponseHeader:      complete.ResponseHeader,
+		CopySourceVersionId: head.VersionId,
+	}
+	if !client.disable_crc && head.HashCRC64 != 0 && complete.HashCRC64 != 0 && head.HashCRC64 != complete.HashCRC64 {
+		return result, &CRCMismatchError{head.HashCRC64, complete.HashCRC64, complete.RequestId}
+	}
+	return result, nil
+}
+
+func (m PartsCopy) single_copy(client *Client) (CopyObjectResult, error) {
+	obj := NewObject(m.path).CopyFrom(m.source)
+	obj.bucket = m.bucket
+	if len(m.headers) > 0 {
+		obj.headers = m.headers
+		obj = obj.MetadataDirective(types.DIRECTIVE_REPLACE)
+	}
+	return obj.Copy(client)
+}
+
+// copy_parts 并发复制所有分片，返回按分片顺序排列的 etag
+func (m PartsCopy) copy_parts(parts *PartsUpload, size int64, client *Client) ([]etag_struct, error) {
+	part_size := m.part_size
+	if size > part_size*MAX_PART_COUNT {
+		part_size = (size + MAX_PART_COUNT - 1) / MAX_PART_COUNT
+	}
+	count := int((size + part_size - 1) / part_size)
+
+	etags := make([]etag_struct, count)
+	errs := make([]error, count)
+
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, m.parallel)
+	for i := 0; i < count; i++ {
+		start := int64(i) * part_size
+		end := min(start+part_size, size) - 1
+
+		wg.Add(1)
+		limit <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-limit }()
+			etags[i], errs[i] = parts.upload_part_copy(i+1, m.source, start, end, client)
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return etags, nil
+}
+
+// source_headers 从源文件的响应头中取出需要保留的元数据和存储类型，
+// 初始化分片上传时不会自动复制源文件的元数据。HEAD 不返回文件的 ACL，所以 ACL 不会保留
+func source_headers(header http.Header) object_headers {
+	keys := []string{
+		HEADER_CONTENT_TYPE,
+		HEADER_CACHE_CONTROL,
+		HEADER_CONTENT_DISPOSITION,
+		HEADER_CONTENT_ENCODING,
+		HEADER_CONTENT_LANGUAGE,
+		HEADER_EXPIRES,
+		HEADER_STORAGE_CLASS,
+	}
+
+	var headers object_headers
+	for _, key := range keys {
+		if value := header.Get(key); len(value) > 0 {
+			headers = headers.with(key, value)
+		}
+	}
+	for key := range header {
+		lower := strings.ToLower(key)
+		if strings.HasPrefix(lower, HEADER_META_PREFIX) {
+			headers = headers.with(lower, header.Get(key))
+		}
+	}
+	return headers
+}
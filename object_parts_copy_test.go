@@ -0,0 +1,68 @@
+package oss
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPartsCopy(t *testing.T) {
+	var mu sync.Mutex
+	var ranges []string
+	var complete_body string
+	var init_header http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.Method == "HEAD":
+			w.Header().Set("Content-Length", "250000")
+			w.Header().Set("Content-Type", "text/plain")
+			w.Header().Set("x-oss-meta-author", "foo")
+			w.Header().Set("x-oss-storage-class", "IA")
+		case r.Method == "POST" && query.Has("uploads"):
+			init_header = r.Header
+			fmt.Fprint(w, "<InitiateMultipartUploadResult><UploadId>abc</UploadId></InitiateMultipartUploadResult>")
+		case r.Method == "PUT" && query.Get("uploadId") == "abc":
+			mu.Lock()
+			ranges = append(ranges, query.Get("partNumber")+":"+r.Header.Get("x-oss-copy-source-range"))
+			mu.Unlock()
+			fmt.Fprintf(w, "<CopyPartResult><ETag>\"etag%s\"</ETag></CopyPartResult>", query.Get("partNumber"))
+		case r.Method == "POST" && query.Get("uploadId") == "abc":
+			body, _ := io.ReadAll(r.Body)
+			complete_body = string(body)
+			fmt.Fprint(w, "<CompleteMultipartUploadResult><ETag>\"final\"</ETag></CompleteMultipartUploadResult>")
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := New("foo", "bar", "honglei123", "cn-shanghai")
+	client.SetBucketDomain(server.URL)
+
+	result, err := NewPartsCopy("dst.txt", NewCopySource(client.Bucket, "src.txt")).
+		PartSize(100 * 1024).Threshold(100).Copy(&client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.ETag != "final" {
+		t.Error("parts copy result error")
+	}
+	if init_header.Get("Content-Type") != "text/plain" || init_header.Get("x-oss-meta-author") != "foo" || init_header.Get("x-oss-storage-class") != "IA" {
+		t.Error("parts copy should keep the source metadata")
+	}
+	slices.Sort(ranges)
+	if !slices.Equal(ranges, []string{"1:bytes=0-102399", "2:bytes=102400-204799", "3:bytes=204800-249999"}) {
+		t.Error("parts copy ranges error:", ranges)
+	}
+	if !strings.Contains(complete_body, "<Part><PartNumber>1</PartNumber><ETag>\"etag1\"</ETag></Part><Part><PartNumber>2</PartNumber>") {
+		t.Error("complete body error:", complete_body)
+	}
+}
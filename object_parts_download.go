@@ -3,6 +3,7 @@ package oss
 import (
 	"bufio"
 	"fmt"
+	"hash/crc64"
 	"io"
 	"net/http"
 	"net/url"
@@ -70,7 +71,7 @@ func (p PartsDownload) Download(client *Client) error {
 			return err
 		}
 		body_string := string(body)
-		return parse_oss_response_error(body_string)
+		return client.response_error(resp, body_string)
 	}
 
 	// 创建本地文件用于保存内容
@@ -84,6 +85,9 @@ func (p PartsDownload) Download(client *Client) error {
 	bufferSize := p.part_size
 	writer := bufio.NewWriterSize(outFile, bufferSize)
 
+	// 边写入文件边计算 crc64
+	hash := crc64.New(crc_table)
+
 	// 使用 io.CopyBuffer 实现分片复制
 	buf := make([]byte, bufferSize)
 	for {
@@ -92,6 +96,7 @@ func (p PartsDownload) Download(client *Client) error {
 			if _, writeErr := writer.Write(buf[:n]); writeErr != nil {
 				return writeErr
 			}
+			hash.Write(buf[:n])
 		}
 		if err != nil {
 			if err == io.EOF {
@@ -106,5 +111,9 @@ func (p PartsDownload) Download(client *Client) error {
 		return err
 	}
 
-	return nil
+	// 自动解压后的内容与服务端的 crc 对不上，不做校验
+	if resp.Uncompressed {
+		return nil
+	}
+	return client.check_crc64(hash.Sum64(), resp.Header)
 }
@@ -8,8 +8,10 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/tu6ge/oss-go/types"
 )
@@ -20,15 +22,34 @@ type PartsUpload struct {
 	file_path string
 	part_size int
 	etag_list []etag_struct
+	headers   object_headers
+	bucket    *Bucket
 }
 
 type etag_struct struct {
 	index   int
 	content string
+	crc     uint64
+	size    int
+	// 复制的分片没有本地的 crc
+	has_crc bool
 }
 
 func NewPartsUpload(path string) PartsUpload {
-	return PartsUpload{path, "", "", 1024 * 1024, []etag_struct{}}
+	return PartsUpload{path, "", "", 1024 * 1024, []etag_struct{}, nil, nil}
+}
+
+// Bucket 指定文件所在的 bucket，不设置时使用 client.Bucket
+func (m PartsUpload) Bucket(bucket Bucket) PartsUpload {
+	m.bucket = &bucket
+	return m
+}
+
+func (m PartsUpload) get_bucket(client *Client) Bucket {
+	if m.bucket != nil {
+		return *m.bucket
+	}
+	return client.Bucket
 }
 
 func (m PartsUpload) ToUrl(bucket *Bucket) url.URL {
@@ -47,22 +68,74 @@ func (m PartsUpload) PartSize(part_size int) PartsUpload {
 	return m
 }
 
-func (m PartsUpload) Upload(client *Client) error {
+func (m PartsUpload) ContentType(value string) PartsUpload {
+	m.headers = m.headers.with(HEADER_CONTENT_TYPE, value)
+	return m
+}
+
+// Meta 设置用户自定义的元数据，即 x-oss-meta-* 请求头，key 不区分大小写
+func (m PartsUpload) Meta(key, value string) PartsUpload {
+	m.headers = m.headers.meta(key, value)
+	return m
+}
+
+func (m PartsUpload) CacheControl(value string) PartsUpload {
+	m.headers = m.headers.with(HEADER_CACHE_CONTROL, value)
+	return m
+}
+
+func (m PartsUpload) ContentDisposition(value string) PartsUpload {
+	m.headers = m.headers.with(HEADER_CONTENT_DISPOSITION, value)
+	return m
+}
+
+func (m PartsUpload) ContentEncoding(value string) PartsUpload {
+	m.headers = m.headers.with(HEADER_CONTENT_ENCODING, value)
+	return m
+}
+
+func (m PartsUpload) ContentLanguage(value string) PartsUpload {
+	m.headers = m.headers.with(HEADER_CONTENT_LANGUAGE, value)
+	return m
+}
+
+func (m PartsUpload) Expires(t time.Time) PartsUpload {
+	m.headers = m.headers.with(HEADER_EXPIRES, t.UTC().Format(http.TimeFormat))
+	return m
+}
+
+func (m PartsUpload) StorageClass(class types.StorageClass) PartsUpload {
+	m.headers = m.headers.with(HEADER_STORAGE_CLASS, string(class))
+	return m
+}
+
+func (m PartsUpload) ACL(acl types.ACL) PartsUpload {
+	m.headers = m.headers.with(HEADER_OBJECT_ACL, string(acl))
+	return m
+}
+
+// ForbidOverwrite 为 true 时，目标文件已存在则初始化分片上传失败
+func (m PartsUpload) ForbidOverwrite(forbid bool) PartsUpload {
+	m.headers = m.headers.with(HEADER_FORBID_OVERWRITE, strconv.FormatBool(forbid))
+	return m
+}
+
+func (m PartsUpload) Upload(client *Client) (CompleteMultipartUploadResult, error) {
 	if len(m.file_path) == 0 {
-		return errors.New("not setting filepath")
+		return CompleteMultipartUploadResult{}, errors.New("not setting filepath")
 	}
 	if m.part_size < 1024*100 {
-		return errors.New("part size not less than 100k")
+		return CompleteMultipartUploadResult{}, errors.New("part size not less than 100k")
 	}
 
 	err := m.InitMulit(client)
 	if err != nil {
-		return err
+		return CompleteMultipartUploadResult{}, err
 	}
 	// 打开大文件
 	file, err := os.Open(m.file_path)
 	if err != nil {
-		return err
+		return CompleteMultipartUploadResult{}, err
 	}
 	defer file.Close()
 
@@ -76,13 +149,13 @@ func (m PartsUpload) Upload(client *Client) error {
 			if err == io.EOF {
 				break // 读到文件尾，退出
 			}
-			return err
+			return CompleteMultipartUploadResult{}, err
 		}
 
 		// 处理每一片数据
 		err = m.UploadPart(chunkIndex, buffer[:n], client)
 		if err != nil {
-			return err
+			return CompleteMultipartUploadResult{}, err
 		}
 
 		chunkIndex++
@@ -92,13 +165,15 @@ func (m PartsUpload) Upload(client *Client) error {
 }
 
 func (m *PartsUpload) InitMulit(client *Client) error {
-	bucket := client.Bucket
+	bucket := m.get_bucket(client)
 	url := m.ToUrl(&bucket)
 	url.RawQuery = "uploads"
 	method := "POST"
 
 	resource := canonicalized_resource(&bucket, m)
-	headers := client.Authorization(method, resource)
+	headers := make(map[string]string)
+	m.headers.apply(headers)
+	headers = client.AuthorizationHeader(method, resource, headers)
 
 	req, err := http.NewRequest(method, url.String(), nil)
 	if err != nil {
@@ -121,7 +196,9 @@ func (m *PartsUpload) InitMulit(client *Client) error {
 		return err
 	}
 	body_string := string(body)
-	// fmt.Println("body_string", body_string)
+	if !http_status_ok(resp.StatusCode) {
+		return client.response_error(resp, body_string)
+	}
 	m.upload_id = parse_upload_id(body_string)
 	if len(m.upload_id) == 0 {
 		return errors.New("not found upload_id")
@@ -131,7 +208,17 @@ func (m *PartsUpload) InitMulit(client *Client) error {
 }
 
 func (m *PartsUpload) UploadPart(index int, con []byte, client *Client) error {
-	bucket := client.Bucket
+	etag, err := m.upload_part(index, con, client)
+	if err != nil {
+		return err
+	}
+	m.etag_list = append(m.etag_list, etag)
+	return nil
+}
+
+// upload_part 只发送请求，不修改 m，可以并发调用
+func (m *PartsUpload) upload_part(index int, con []byte, client *Client) (etag_struct, error) {
+	bucket := m.get_bucket(client)
 	url := m.ToUrl(&bucket)
 	url.RawQuery = fmt.Sprintf("partNumber=%d&uploadId=%s", index, m.upload_id)
 	method := "PUT"
@@ -141,11 +228,14 @@ func (m *PartsUpload) UploadPart(index int, con []byte, client *Client) error {
 	headers := map[string]string{
 		"Content-Length": strconv.Itoa(len(con)),
 	}
+	if client.enable_md5 {
+		headers["Content-MD5"] = content_md5(con)
+	}
 	headers = client.AuthorizationHeader(method, resource, headers)
 
 	req, err := http.NewRequest(method, url.String(), bytes.NewReader([]byte(con)))
 	if err != nil {
-		return err
+		return etag_struct{}, err
 	}
 
 	for k, v := range headers {
@@ -154,29 +244,122 @@ func (m *PartsUpload) UploadPart(index int, con []byte, client *Client) error {
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return err
+		return etag_struct{}, err
+	}
+	defer resp.Body.Close()
+
+	if !http_status_ok(resp.StatusCode) {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return etag_struct{}, err
+		}
+		return etag_struct{}, client.response_error(resp, string(body))
 	}
 	etag := resp.Header.Get("ETag")
 	if len(etag) == 0 {
-		return errors.New("not found etag header")
+		return etag_struct{}, errors.New("not found etag header")
+	}
+
+	crc := crc64_checksum(con)
+	if err := client.check_crc64(crc, resp.Header); err != nil {
+		return etag_struct{}, err
 	}
 
-	m.etag_list = append(m.etag_list, etag_struct{index, etag})
+	return etag_struct{index, etag, crc, len(con), true}, nil
+}
 
+// UploadPartCopy 从源文件复制 [start, end] 字节范围作为第 index 个分片
+func (m *PartsUpload) UploadPartCopy(index int, source CopySource, start, end int64, client *Client) error {
+	etag, err := m.upload_part_copy(index, source, start, end, client)
+	if err != nil {
+		return err
+	}
+	m.etag_list = append(m.etag_list, etag)
 	return nil
 }
 
+// upload_part_copy 只发送请求，不修改 m，可以并发调用
+func (m *PartsUpload) upload_part_copy(index int, source CopySource, start, end int64, client *Client) (etag_struct, error) {
+	bucket := m.get_bucket(client)
+	url := m.ToUrl(&bucket)
+	url.RawQuery = fmt.Sprintf("partNumber=%d&uploadId=%s", index, m.upload_id)
+	method := "PUT"
+
+	resource := canonicalized_resource_part(&bucket, m, index, m.upload_id)
+
+	headers := map[string]string{
+		HEADER_COPY_SOURCE:       source.String(),
+		HEADER_COPY_SOURCE_RANGE: fmt.Sprintf("bytes=%d-%d", start, end),
+	}
+	headers = client.AuthorizationHeader(method, resource, headers)
+
+	req, err := http.NewRequest(method, url.String(), nil)
+	if err != nil {
+		return etag_struct{}, err
+	}
+
+	for k, v := range headers {
+		req.Header.Add(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return etag_struct{}, err
+	}
+	defer resp.Body.Close()
+
+	// 读取响应体
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return etag_struct{}, err
+	}
+	body_string := string(body)
+
+	if !http_status_ok(resp.StatusCode) {
+		return etag_struct{}, client.response_error(resp, body_string)
+	}
+
+	etag := parse_item(body_string, "ETag")
+	if len(etag) == 0 {
+		return etag_struct{}, errors.New("not found etag")
+	}
+
+	return etag_struct{index, etag, 0, int(end - start + 1), false}, nil
+}
+
 func (m *PartsUpload) etag_list_xml() string {
 	list := ""
-	for _, item := range m.etag_list {
+	sorted := slices.Clone(m.etag_list)
+	slices.SortFunc(sorted, func(a, b etag_struct) int {
+		return a.index - b.index
+	})
+	for _, item := range sorted {
 		list += fmt.Sprintf("<Part><PartNumber>%d</PartNumber><ETag>%s</ETag></Part>", item.index, item.content)
 	}
 
 	return fmt.Sprintf("<CompleteMultipartUpload>%s</CompleteMultipartUpload>", list)
 }
 
-func (m *PartsUpload) Complete(client *Client) error {
-	bucket := client.Bucket
+// crc64 按分片顺序合并所有分片的 crc，得到整个文件的 crc，
+// 有分片没有本地 crc 时返回 false
+func (m *PartsUpload) crc64() (uint64, bool) {
+	list := slices.Clone(m.etag_list)
+	slices.SortFunc(list, func(a, b etag_struct) int {
+		return a.index - b.index
+	})
+
+	var crc uint64
+	for _, item := range list {
+		if !item.has_crc {
+			return 0, false
+		}
+		crc = crc64_combine(crc, item.crc, uint64(item.size))
+	}
+	return crc, true
+}
+
+func (m *PartsUpload) Complete(client *Client) (CompleteMultipartUploadResult, error) {
+	bucket := m.get_bucket(client)
 	url := m.ToUrl(&bucket)
 	url.RawQuery = fmt.Sprintf("uploadId=%s", m.upload_id)
 	method := "POST"
@@ -191,6 +374,52 @@ func (m *PartsUpload) Complete(client *Client) error {
 	headers = client.AuthorizationHeader(method, resource, headers)
 
 	req, err := http.NewRequest(method, url.String(), bytes.NewReader([]byte(xml)))
+	if err != nil {
+		return CompleteMultipartUploadResult{}, err
+	}
+
+	for k, v := range headers {
+		req.Header.Add(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return CompleteMultipartUploadResult{}, err
+	}
+
+	defer resp.Body.Close()
+
+	// 读取响应体
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return CompleteMultipartUploadResult{}, err
+	}
+	body_string := string(body)
+
+	if http_status_ok(resp.StatusCode) {
+		result := parse_complete_multipart_upload_result(resp.Header, body_string)
+		if crc, ok := m.crc64(); ok {
+			if err := client.check_crc64(crc, resp.Header); err != nil {
+				return result, err
+			}
+		}
+		return result, nil
+	} else {
+		return CompleteMultipartUploadResult{}, client.response_error(resp, body_string)
+	}
+}
+
+// Abort 取消分片上传，已上传的分片会被删除
+func (m *PartsUpload) Abort(client *Client) error {
+	bucket := m.get_bucket(client)
+	url := m.ToUrl(&bucket)
+	url.RawQuery = fmt.Sprintf("uploadId=%s", m.upload_id)
+	method := "DELETE"
+
+	resource := canonicalized_resource_complete(&bucket, m, m.upload_id)
+	headers := client.Authorization(method, resource)
+
+	req, err := http.NewRequest(method, url.String(), nil)
 	if err != nil {
 		return err
 	}
@@ -215,7 +444,7 @@ func (m *PartsUpload) Complete(client *Client) error {
 			return err
 		}
 		body_string := string(body)
-		return parse_oss_response_error(body_string)
+		return client.response_error(resp, body_string)
 	}
 }
 
@@ -0,0 +1,125 @@
+package oss
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+type restore_request struct {
+	XMLName       xml.Name `xml:"RestoreRequest"`
+	Days          int
+	JobParameters *restore_job_parameters `xml:",omitempty"`
+}
+
+type restore_job_parameters struct {
+	Tier types.RestoreTier
+}
+
+// Restore 解冻归档类型的文件，days 为解冻后保持可读的天数，
+// tier 只对冷归档、深度冷归档生效，归档类型传空字符串即可。
+// 已经在解冻中时返回 ErrRestoreAlreadyInProgress
+func (obj Object) Restore(client *Client, days int, tier types.RestoreTier) error {
+	request := restore_request{Days: days}
+	if len(tier) > 0 {
+		request.JobParameters = &restore_job_parameters{tier}
+	}
+	body, err := xml.Marshal(request)
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{
+		"Content-Length": strconv.Itoa(len(body)),
+		"Content-MD5":    content_md5(body),
+	}
+	_, err = obj.sub_resource_request(client, "POST", "restore", headers, body)
+	return err
+}
+
+// WaitRestore 默认的查询间隔
+const RESTORE_CHECK_INTERVAL = 30 * time.Second
+
+// 文件没有提交过解冻请求时，WaitRestore 返回这个错误
+var ErrRestoreNotRequested = errors.New("object has no restore request")
+
+// WaitRestore 每隔 interval 查询一次解冻状态，直到解冻完成或 ctx 结束，
+// interval 不大于 0 时使用 RESTORE_CHECK_INTERVAL
+func (obj Object) WaitRestore(ctx context.Context, client *Client, interval time.Duration) (HeadObjectResult, error) {
+	if interval <= 0 {
+		interval = RESTORE_CHECK_INTERVAL
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		head, err := obj.Head(client)
+		if err != nil {
+			return head, err
+		}
+		if head.Restore.Restored() {
+			return head, nil
+		}
+		if !head.Restore.Requested {
+			return head, ErrRestoreNotRequested
+		}
+
+		select {
+		case <-ctx.Done():
+			return head, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RestoreStatus x-oss-restore 响应头中的解冻状态
+type RestoreStatus struct {
+	// 文件是否提交过解冻请求
+	Requested bool
+	// 是否正在解冻中
+	Ongoing bool
+	// 解冻完成后可以读取的截止时间
+	ExpiryDate time.Time
+}
+
+// Restored 解冻已经完成，可以下载
+func (s RestoreStatus) Restored() bool {
+	return s.Requested && !s.Ongoing
+}
+
+// parse_restore_status 解析 ongoing-request="false", expiry-date="Sun, 16 Apr 2017 08:12:33 GMT"
+func parse_restore_status(value string) RestoreStatus {
+	var status RestoreStatus
+	if len(value) == 0 {
+		return status
+	}
+	status.Requested = true
+
+	for len(value) > 0 {
+		eq := strings.Index(value, `="`)
+		if eq == -1 {
+			break
+		}
+		key := strings.TrimSpace(strings.TrimLeft(value[:eq], ", "))
+		rest := value[eq+2:]
+		end := strings.Index(rest, `"`)
+		if end == -1 {
+			break
+		}
+		item := rest[:end]
+		value = rest[end+1:]
+
+		switch key {
+		case "ongoing-request":
+			status.Ongoing = item == "true"
+		case "expiry-date":
+			status.ExpiryDate, _ = http.ParseTime(item)
+		}
+	}
+	return status
+}
@@ -0,0 +1,64 @@
+package oss
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+func TestParseRestoreStatus(t *testing.T) {
+	status := parse_restore_status(`ongoing-request="true"`)
+	if !status.Requested || !status.Ongoing || status.Restored() {
+		t.Error("parse ongoing restore status error")
+	}
+
+	status = parse_restore_status(`ongoing-request="false", expiry-date="Sun, 16 Apr 2017 08:12:33 GMT"`)
+	if !status.Restored() || !status.ExpiryDate.Equal(time.Date(2017, 4, 16, 8, 12, 33, 0, time.UTC)) {
+		t.Error("parse restored status error")
+	}
+
+	if parse_restore_status("").Requested {
+		t.Error("empty restore header should not be requested")
+	}
+
+	data, _ := xml.Marshal(restore_request{Days: 2, JobParameters: &restore_job_parameters{types.RESTORE_TIER_BULK}})
+	if string(data) != "<RestoreRequest><Days>2</Days><JobParameters><Tier>Bulk</Tier></JobParameters></RestoreRequest>" {
+		t.Error("restore request xml error:", string(data))
+	}
+	data, _ = xml.Marshal(restore_request{Days: 2})
+	if string(data) != "<RestoreRequest><Days>2</Days></RestoreRequest>" {
+		t.Error("restore request xml error:", string(data))
+	}
+}
+
+func TestWaitRestore(t *testing.T) {
+	restore := ""
+	heads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		heads++
+		if len(restore) > 0 {
+			w.Header().Set("x-oss-restore", restore)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := New("foo", "bar", "honglei123", "cn-shanghai")
+	client.SetBucketDomain(server.URL)
+
+	_, err := NewObject("a.txt").WaitRestore(context.Background(), &client, 0)
+	if !errors.Is(err, ErrRestoreNotRequested) || heads != 1 {
+		t.Error("wait restore without request should fail at once:", err)
+	}
+
+	restore = `ongoing-request="false", expiry-date="Sun, 16 Apr 2017 08:12:33 GMT"`
+	head, err := NewObject("a.txt").WaitRestore(context.Background(), &client, 0)
+	if err != nil || !head.Restore.Restored() {
+		t.Error("wait restore error:", err)
+	}
+}
@@ -0,0 +1,44 @@
+package oss
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// sub_resource_request 发送 ?tagging、?acl 等子资源的请求，返回响应体
+func (obj Object) sub_resource_request(client *Client, method, sub_resource string, headers map[string]string, body []byte) ([]byte, error) {
+	bucket := obj.get_bucket(client)
+	url := obj.ToUrl(&bucket)
+	url.RawQuery = obj.version_query(sub_resource)
+
+	resource := canonicalized_resource_version(&bucket, &obj, sub_resource)
+	headers = client.AuthorizationHeader(method, resource, headers)
+
+	req, err := http.NewRequest(method, url.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range headers {
+		req.Header.Add(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	// 读取响应体
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if !http_status_ok(resp.StatusCode) {
+		return nil, client.response_error(resp, string(data))
+	}
+	return data, nil
+}
@@ -0,0 +1,124 @@
+package oss
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+const HEADER_SYMLINK_TARGET = "x-oss-symlink-target"
+
+type GetSymlinkResult struct {
+	ResponseHeader
+	// 软链接指向的文件名
+	Target       string
+	LastModified time.Time
+}
+
+// PutSymlink 把当前文件设置为指向 target 的软链接，
+// 元数据、存储类型、访问权限和 ForbidOverwrite 等设置同样生效
+func (obj Object) PutSymlink(client *Client, target string) (PutObjectResult, error) {
+	bucket := obj.get_bucket(client)
+	url := obj.ToUrl(&bucket)
+	url.RawQuery = "symlink"
+	method := "PUT"
+
+	resource := canonicalized_resource_symlink(&bucket, &obj)
+	headers := make(map[string]string)
+	obj.headers.apply(headers)
+	headers[HEADER_SYMLINK_TARGET] = escape_key(target)
+	headers = client.AuthorizationHeader(method, resource, headers)
+
+	req, err := http.NewRequest(method, url.String(), nil)
+	if err != nil {
+		return PutObjectResult{}, err
+	}
+
+	for k, v := range headers {
+		req.Header.Add(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return PutObjectResult{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if http_status_ok(resp.StatusCode) {
+		return PutObjectResult{new_response_header(resp.Header)}, nil
+	} else {
+		// 读取响应体
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return PutObjectResult{}, err
+		}
+		body_string := string(body)
+		return PutObjectResult{}, client.response_error(resp, body_string)
+	}
+}
+
+// GetSymlink 获取软链接指向的文件
+func (obj Object) GetSymlink(client *Client) (GetSymlinkResult, error) {
+	bucket := obj.get_bucket(client)
+	url := obj.ToUrl(&bucket)
+	url.RawQuery = "symlink"
+	method := "GET"
+
+	resource := canonicalized_resource_symlink(&bucket, &obj)
+	headers := client.Authorization(method, resource)
+
+	req, err := http.NewRequest(method, url.String(), nil)
+	if err != nil {
+		return GetSymlinkResult{}, err
+	}
+
+	for k, v := range headers {
+		req.Header.Add(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return GetSymlinkResult{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if http_status_ok(resp.StatusCode) {
+		return parse_get_symlink_result(resp.Header), nil
+	} else {
+		// 读取响应体
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return GetSymlinkResult{}, err
+		}
+		body_string := string(body)
+		return GetSymlinkResult{}, client.response_error(resp, body_string)
+	}
+}
+
+func parse_get_symlink_result(header http.Header) GetSymlinkResult {
+	target := header.Get(HEADER_SYMLINK_TARGET)
+	if unescaped, err := url.QueryUnescape(target); err == nil {
+		target = unescaped
+	}
+	result := GetSymlinkResult{
+		ResponseHeader: new_response_header(header),
+		Target:         target,
+	}
+	result.LastModified, _ = http.ParseTime(header.Get("Last-Modified"))
+	return result
+}
+
+// escape_key 对文件名进行 url 编码，与 x-oss-copy-source 的编码方式一致
+func escape_key(key string) string {
+	return url.QueryEscape(key)
+}
+
+func canonicalized_resource_symlink(bucket *Bucket, object *Object) types.CanonicalizedResource {
+	return types.NewCanonicalizedResource(fmt.Sprintf("/%s/%s?symlink", bucket.name, object.path))
+}
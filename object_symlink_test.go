@@ -0,0 +1,22 @@
+package oss
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseGetSymlinkResult(t *testing.T) {
+	header := http.Header{}
+	header.Set("x-oss-symlink-target", escape_key("builds/v1.2.3/app linux.tar.gz"))
+	header.Set("Last-Modified", "Fri, 01 Mar 2024 08:00:00 GMT")
+
+	result := parse_get_symlink_result(header)
+	if result.Target != "builds/v1.2.3/app linux.tar.gz" || result.LastModified.IsZero() {
+		t.Error("parse symlink result error:", result.Target)
+	}
+
+	header.Set("x-oss-object-type", "Symlink")
+	if !parse_head_object_result(header, 0).IsSymlink() {
+		t.Error("head result should report symlink")
+	}
+}
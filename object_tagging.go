@@ -0,0 +1,99 @@
+package oss
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/url"
+	"strconv"
+)
+
+const HEADER_TAGGING = "x-oss-tagging"
+
+type Tag struct {
+	Key   string
+	Value string
+}
+
+// TagSet 文件的标签，最多 10 个
+type TagSet []Tag
+
+// NewTagSet 使用 key, value 交替的参数创建标签，例如 NewTagSet("env", "prod", "team", "infra")
+func NewTagSet(pairs ...string) TagSet {
+	var tags TagSet
+	for i := 0; i+1 < len(pairs); i += 2 {
+		tags = append(tags, Tag{pairs[i], pairs[i+1]})
+	}
+	return tags
+}
+
+// Get 返回 key 对应的标签值
+func (t TagSet) Get(key string) (string, bool) {
+	for _, tag := range t {
+		if tag.Key == key {
+			return tag.Value, true
+		}
+	}
+	return "", false
+}
+
+// Encode 编码为 x-oss-tagging 请求头使用的格式，即 k1=v1&k2=v2
+func (t TagSet) Encode() string {
+	var buf bytes.Buffer
+	for i, tag := range t {
+		if i > 0 {
+			buf.WriteByte('&')
+		}
+		buf.WriteString(url.QueryEscape(tag.Key))
+		buf.WriteByte('=')
+		buf.WriteString(url.QueryEscape(tag.Value))
+	}
+	return buf.String()
+}
+
+type tagging_xml struct {
+	XMLName xml.Name `xml:"Tagging"`
+	Tags    TagSet   `xml:"TagSet>Tag"`
+}
+
+// Tagging 上传时设置文件的标签
+func (obj Object) Tagging(tags TagSet) Object {
+	obj.headers = obj.headers.with(HEADER_TAGGING, tags.Encode())
+	return obj
+}
+
+// Tagging 上传时设置文件的标签
+func (m PartsUpload) Tagging(tags TagSet) PartsUpload {
+	m.headers = m.headers.with(HEADER_TAGGING, tags.Encode())
+	return m
+}
+
+// PutTagging 设置文件的标签，会覆盖原有的所有标签
+func (obj Object) PutTagging(client *Client, tags TagSet) error {
+	body, err := xml.Marshal(tagging_xml{Tags: tags})
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{
+		"Content-Length": strconv.Itoa(len(body)),
+		"Content-MD5":    content_md5(body),
+	}
+	_, err = obj.sub_resource_request(client, "PUT", "tagging", headers, body)
+	return err
+}
+
+func (obj Object) GetTagging(client *Client) (TagSet, error) {
+	data, err := obj.sub_resource_request(client, "GET", "tagging", make(map[string]string), nil)
+	if err != nil {
+		return nil, err
+	}
+	var result tagging_xml
+	if err := xml.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result.Tags, nil
+}
+
+func (obj Object) DeleteTagging(client *Client) error {
+	_, err := obj.sub_resource_request(client, "DELETE", "tagging", make(map[string]string), nil)
+	return err
+}
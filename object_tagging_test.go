@@ -0,0 +1,29 @@
+package oss
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestTagSet(t *testing.T) {
+	tags := NewTagSet("env", "prod", "owner", "a&b")
+	if tags.Encode() != "env=prod&owner=a%26b" {
+		t.Error("encode tag set error:", tags.Encode())
+	}
+
+	data, _ := xml.Marshal(tagging_xml{Tags: tags})
+	if string(data) != "<Tagging><TagSet><Tag><Key>env</Key><Value>prod</Value></Tag><Tag><Key>owner</Key><Value>a&amp;b</Value></Tag></TagSet></Tagging>" {
+		t.Error("tagging xml error:", string(data))
+	}
+
+	var result tagging_xml
+	xml.Unmarshal(data, &result)
+	if value, ok := result.Tags.Get("owner"); !ok || value != "a&b" {
+		t.Error("parse tagging xml error")
+	}
+
+	obj := NewObject("a.txt").Tagging(tags)
+	if obj.headers[HEADER_TAGGING] != "env=prod&owner=a%26b" {
+		t.Error("object tagging header error")
+	}
+}
@@ -1,19 +1,12 @@
 package oss
 
 import (
-	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"net/url"
-	"os"
 	"sort"
 	"strings"
-	"time"
 
 	"github.com/tu6ge/oss-go/types"
-
-	"github.com/joho/godotenv"
 )
 
 var (
@@ -23,12 +16,18 @@ var (
 	QUERY_PREFIX             = types.QUERY_PREFIX
 	QUERY_ENCODING_TYPE      = types.QUERY_ENCODING_TYPE
 	QUERY_FETCH_OWNER        = types.QUERY_FETCH_OWNER
+	QUERY_DELIMITER          = types.QUERY_DELIMITER
+	QUERY_KEY_MARKER         = types.QUERY_KEY_MARKER
+	QUERY_VERSION_ID_MARKER  = types.QUERY_VERSION_ID_MARKER
 )
 
 type Client struct {
 	access_key_id    string
 	access_secret_id types.Secret
 	Bucket           Bucket
+	clock            *clock
+	disable_crc      bool
+	enable_md5       bool
 }
 
 func New(key, secret, bucket, endpoint string) (Client, error) {
@@ -40,29 +39,36 @@ func New(key, secret, bucket, endpoint string) (Client, error) {
 		key,
 		types.NewSecret(secret),
 		bucket_name,
+		new_clock(),
+		false,
+		false,
 	}, nil
 }
 
-func NewWithEnv() (Client, error) {
-	err := godotenv.Load()
+// NewWithEnv 从进程的环境变量中读取配置，files 为可选的 dotenv 文件，
+// 不传时会尝试加载当前目录下的 .env（不存在也不会报错）
+func NewWithEnv(files ...string) (Client, error) {
+	err := types.LoadEnv(files...)
 	if err != nil {
 		return Client{}, err
 	}
 
 	// 读取环境变量
-	key_id := os.Getenv("ALIYUN_KEY_ID")
-	secret_id := os.Getenv("ALIYUN_KEY_SECRET")
-
-	if key_id == "" || secret_id == "" {
-		return Client{}, &EnvEmtpyError{}
+	key_id, err := types.LookupEnv(types.ENV_KEY_ID...)
+	if err != nil {
+		return Client{}, err
+	}
+	secret_id, err := types.LookupEnv(types.ENV_KEY_SECRET...)
+	if err != nil {
+		return Client{}, err
 	}
 
-	bucket, err := BucketFromEnv()
+	bucket, err := BucketFromEnv(files...)
 	if err != nil {
 		return Client{}, err
 	}
 
-	return Client{key_id, types.NewSecret(secret_id), bucket}, nil
+	return Client{key_id, types.NewSecret(secret_id), bucket, new_clock(), false, false}, nil
 }
 
 func (c Client) Authorization(method string, resource types.CanonicalizedResource) map[string]string {
@@ -75,7 +81,7 @@ const (
 )
 
 func (c Client) AuthorizationHeader(method string, resource types.CanonicalizedResource, headers map[string]string) map[string]string {
-	date := now()
+	date := c.now()
 
 	resource_str := resource.ToStr()
 
@@ -85,6 +91,7 @@ func (c Client) AuthorizationHeader(method string, resource types.CanonicalizedR
 
 	sign_str := method
 	sign_str += LINE_BREAK
+	sign_str += headers["Content-MD5"]
 	sign_str += LINE_BREAK
 	if ok_content_type {
 		sign_str += content_type
@@ -117,118 +124,15 @@ func (c *Client) SetBucketDomain(domain string) {
 	c.Bucket.SetDomain(domain)
 }
 
+// GetBuckets 获取 endpoint 下的所有 bucket，不传 endpoint 时使用 client.Bucket 的 endpoint
 func (c Client) GetBuckets(endpoint ...types.EndPoint) ([]Bucket, error) {
-	var url url.URL
-	var end types.EndPoint
-	if len(endpoint) == 0 {
-		end = c.Bucket.endpoint
-		url = end.ToUrl()
-	} else if len(endpoint) == 1 {
-		end = endpoint[0]
-		url = end.ToUrl()
-	} else {
-		return []Bucket{}, errors.New("too many args")
-	}
-	method := "GET"
-	resource := types.DefaultCanonicalizedResource()
-
-	header_map := c.Authorization(method, resource)
-
-	req, err := http.NewRequest(method, url.String(), nil)
-	if err != nil {
-		return []Bucket{}, err
-	}
-
-	for k, v := range header_map {
-		req.Header.Add(k, v)
-	}
-
-	client := http.DefaultClient
-	resp, err := client.Do(req)
-	if err != nil {
-		return []Bucket{}, err
-	}
-
-	defer resp.Body.Close() // 关闭响应体
-
-	// 读取响应体
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return []Bucket{}, err
-	}
-
-	body_string := string(body)
-
-	if http_status_ok(resp.StatusCode) {
-		return parser_xml(body_string, end), nil
-	} else {
-		return nil, parse_oss_response_error(body_string)
-	}
+	return c.ListBuckets(NewBucketQuery(), endpoint...)
 }
 
 func http_status_ok(status int) bool {
 	return status >= http.StatusOK && status < http.StatusMultipleChoices
 }
 
-func parser_xml(xml string, endpoint types.EndPoint) []Bucket {
-	var start_positions []int
-	var end_positions []int
-	start := 0
-	pattern := "<Name>"
-	pattern_len := len(pattern)
-
-	pos := strings.Index(xml[start:], pattern)
-	for pos > -1 {
-		start_positions = append(start_positions, pos)
-		start = pos + pattern_len
-		if start > len(xml) {
-			break
-		}
-		pos = strings.Index(xml[start:], pattern)
-		if pos == -1 {
-			break
-		}
-		pos = pos + start
-	}
-
-	start = 0
-	pattern = "</Name>"
-	pattern_len = len(pattern)
-	pos = strings.Index(xml[start:], pattern)
-	for pos > -1 {
-		end_positions = append(end_positions, pos)
-		start = pos + pattern_len
-		if start > len(xml) {
-			break
-		}
-		pos = strings.Index(xml[start:], pattern)
-		if pos == -1 {
-			break
-		}
-		pos = pos + start
-	}
-
-	var buckets []Bucket
-	for i, item := range start_positions {
-		name := xml[item+len("<Name>") : end_positions[i]]
-
-		buckets = append(buckets, Bucket{name, endpoint, types.NewObjectQuery(), ""})
-	}
-	return buckets
-}
-
-func now() string {
-	// 获取当前时间并转换为 UTC
-	currentTime := time.Now().UTC()
-
-	// 格式化时间为 RFC1123 格式（带 GMT）
-	formattedTime := currentTime.Format(time.RFC1123)
-
-	// 将 "UTC" 替换为 "GMT"
-	formattedTime = replaceUTCWithGMT(formattedTime)
-	return formattedTime
-}
-
 // 替换 UTC 为 GMT
 func replaceUTCWithGMT(timeStr string) string {
 	if len(timeStr) > 3 && timeStr[len(timeStr)-3:] == "UTC" {
@@ -37,7 +37,11 @@ func TestSecretEncryption(t *testing.T) {
   </Buckets>
 </ListAllMyBucketsResult>`
 	endpoint, _ := types.NewEndPoint("oss-qingdao")
-	buckets := parser_xml(xml, endpoint)
+	result, err := parse_list_buckets([]byte(xml), endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buckets := result.buckets
 
 	if buckets[0].name != "aliyun-wb-kpbf3" || buckets[1].name != "honglei123" {
 		t.Error("parser xml failed")
@@ -0,0 +1,145 @@
+package oss
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tu6ge/oss-go/types"
+)
+
+// ResponseHeader 写操作响应头中的公共信息
+type ResponseHeader struct {
+	RequestId                 string
+	ETag                      string
+	VersionId                 string
+	HashCRC64                 uint64
+	ServerSideEncryption      string
+	ServerSideEncryptionKeyId string
+}
+
+func new_response_header(header http.Header) ResponseHeader {
+	crc, _ := strconv.ParseUint(header.Get("x-oss-hash-crc64ecma"), 10, 64)
+	return ResponseHeader{
+		RequestId:                 header.Get("x-oss-request-id"),
+		ETag:                      trim_etag(header.Get("ETag")),
+		VersionId:                 header.Get("x-oss-version-id"),
+		HashCRC64:                 crc,
+		ServerSideEncryption:      header.Get("x-oss-server-side-encryption"),
+		ServerSideEncryptionKeyId: header.Get("x-oss-server-side-encryption-key-id"),
+	}
+}
+
+type PutObjectResult struct {
+	ResponseHeader
+}
+
+type CopyObjectResult struct {
+	ResponseHeader
+	// 源文件的版本 id
+	CopySourceVersionId string
+	LastModified        time.Time
+}
+
+type DeleteObjectResult struct {
+	RequestId    string
+	VersionId    string
+	DeleteMarker bool
+}
+
+type CompleteMultipartUploadResult struct {
+	ResponseHeader
+	Location string
+	Bucket   string
+	Key      string
+}
+
+type HeadObjectResult struct {
+	ResponseHeader
+	ContentLength int64
+	ContentType   string
+	LastModified  time.Time
+	StorageClass  types.StorageClass
+	// Normal、Multipart、Appendable 或 Symlink
+	ObjectType string
+	// 指定的版本是删除标记
+	DeleteMarker bool
+	// 归档类型文件的解冻状态
+	Restore RestoreStatus
+	// 用户自定义的元数据，key 为去掉 x-oss-meta- 前缀后的小写形式
+	Meta   map[string]string
+	Header http.Header
+}
+
+// IsSymlink 文件是否为软链接
+func (r HeadObjectResult) IsSymlink() bool {
+	return r.ObjectType == "Symlink"
+}
+
+type GetObjectResult struct {
+	HeadObjectResult
+	Content []byte
+}
+
+func parse_head_object_result(header http.Header, content_length int64) HeadObjectResult {
+	result := HeadObjectResult{
+		ResponseHeader: new_response_header(header),
+		ContentLength:  content_length,
+		ContentType:    header.Get("Content-Type"),
+		StorageClass:   types.StorageClass(header.Get("x-oss-storage-class")),
+		ObjectType:     header.Get("x-oss-object-type"),
+		DeleteMarker:   header.Get("x-oss-delete-marker") == "true",
+		Restore:        parse_restore_status(header.Get("x-oss-restore")),
+		Meta:           make(map[string]string),
+		Header:         header,
+	}
+	result.LastModified, _ = http.ParseTime(header.Get("Last-Modified"))
+	for key := range header {
+		lower := strings.ToLower(key)
+		if strings.HasPrefix(lower, HEADER_META_PREFIX) {
+			result.Meta[strings.TrimPrefix(lower, HEADER_META_PREFIX)] = header.Get(key)
+		}
+	}
+	return result
+}
+
+func parse_copy_object_result(header http.Header, xml string) CopyObjectResult {
+	result := CopyObjectResult{
+		ResponseHeader:      new_response_header(header),
+		CopySourceVersionId: header.Get("x-oss-copy-source-version-id"),
+	}
+	if etag := parse_item(xml, "ETag"); len(etag) > 0 {
+		result.ETag = trim_etag(etag)
+	}
+	result.LastModified, _ = time.Parse(time.RFC3339, parse_item(xml, "LastModified"))
+	return result
+}
+
+func parse_delete_object_result(header http.Header) DeleteObjectResult {
+	return DeleteObjectResult{
+		RequestId:    header.Get("x-oss-request-id"),
+		VersionId:    header.Get("x-oss-version-id"),
+		DeleteMarker: header.Get("x-oss-delete-marker") == "true",
+	}
+}
+
+func parse_complete_multipart_upload_result(header http.Header, xml string) CompleteMultipartUploadResult {
+	result := CompleteMultipartUploadResult{
+		ResponseHeader: new_response_header(header),
+		Location:       parse_item(xml, "Location"),
+		Bucket:         parse_item(xml, "Bucket"),
+		Key:            parse_item(xml, "Key"),
+	}
+	if etag := parse_item(xml, "ETag"); len(etag) > 0 {
+		result.ETag = trim_etag(etag)
+	}
+	return result
+}
+
+// trim_etag 去掉 ETag 两侧的引号，xml 中的引号可能被转义
+func trim_etag(etag string) string {
+	etag = strings.TrimPrefix(etag, "&quot;")
+	etag = strings.TrimSuffix(etag, "&quot;")
+	return strings.Trim(etag, "\"")
+}
@@ -0,0 +1,28 @@
+package oss
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseCopyObjectResult(t *testing.T) {
+	header := http.Header{}
+	header.Set("x-oss-request-id", "abc")
+	header.Set("x-oss-version-id", "CAEQNhiBgMDJgZCA0BYiIDc4MGZjZGI2OTBjOTRmNTE5NmU5NmFhZjhjYmY0****")
+	header.Set("x-oss-hash-crc64ecma", "12345678901234567890")
+
+	xml := `<?xml version="1.0" encoding="UTF-8"?>
+<CopyObjectResult>
+  <ETag>"5B3C1A2E053D763E1B002CC607C5****"</ETag>
+  <LastModified>2019-04-09T03:45:32.000Z</LastModified>
+</CopyObjectResult>`
+
+	result := parse_copy_object_result(header, xml)
+	if result.ETag != "5B3C1A2E053D763E1B002CC607C5****" || result.RequestId != "abc" || result.HashCRC64 != 12345678901234567890 {
+		t.Error("parse copy object result failed")
+	}
+	if !result.LastModified.Equal(time.Date(2019, 4, 9, 3, 45, 32, 0, time.UTC)) {
+		t.Error("parse last modified failed")
+	}
+}
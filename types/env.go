@@ -0,0 +1,52 @@
+package types
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+// 每个配置项可以使用的环境变量名，按顺序查找，取第一个非空的值
+var (
+	ENV_KEY_ID     = []string{"ALIYUN_KEY_ID", "OSS_ACCESS_KEY_ID", "ALIBABA_CLOUD_ACCESS_KEY_ID"}
+	ENV_KEY_SECRET = []string{"ALIYUN_KEY_SECRET", "OSS_ACCESS_KEY_SECRET", "ALIBABA_CLOUD_ACCESS_KEY_SECRET"}
+	ENV_ENDPOINT   = []string{"ALIYUN_ENDPOINT", "OSS_ENDPOINT"}
+	ENV_BUCKET     = []string{"ALIYUN_BUCKET", "OSS_BUCKET"}
+)
+
+// LoadEnv 把 dotenv 文件合并到进程的环境变量中，已经存在的环境变量不会被覆盖。
+//
+// 不传文件名时尝试加载当前目录下的 .env，文件不存在则忽略；
+// 显式传入的文件不存在时返回错误。
+func LoadEnv(files ...string) error {
+	if len(files) > 0 {
+		return godotenv.Load(files...)
+	}
+
+	err := godotenv.Load()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// LookupEnv 依次读取 names 中的环境变量，全部为空时返回 *EnvEmtpyError
+func LookupEnv(names ...string) (string, error) {
+	for _, name := range names {
+		if value := os.Getenv(name); len(value) > 0 {
+			return value, nil
+		}
+	}
+	return "", &EnvEmtpyError{names}
+}
+
+type EnvEmtpyError struct {
+	Names []string
+}
+
+func (e *EnvEmtpyError) Error() string {
+	return "environment variable " + strings.Join(e.Names, " or ") + " is empty"
+}
@@ -5,11 +5,8 @@ import (
 	"crypto/sha1"
 	"encoding/base64"
 	"net/url"
-	"os"
 	"strings"
 	"unicode"
-
-	"github.com/joho/godotenv"
 )
 
 type Secret struct {
@@ -61,6 +58,8 @@ const (
 	QUERY_PREFIX             string = "prefix"
 	QUERY_ENCODING_TYPE      string = "encoding-type"
 	QUERY_FETCH_OWNER        string = "fetch-owner"
+	QUERY_KEY_MARKER         string = "key-marker"
+	QUERY_VERSION_ID_MARKER  string = "version-id-marker"
 )
 
 func NewObjectQuery() ObjectQuery {
@@ -86,7 +85,7 @@ func (q ObjectQuery) ToOssQuery() string {
 		query_str += "&"
 		query_str += key
 		query_str += "="
-		query_str += value
+		query_str += url.QueryEscape(value)
 	}
 	return query_str
 }
@@ -118,12 +117,15 @@ const (
 	ENDPOINT_US_EAST_1   string = "us-east-1"
 )
 
-func EndPointFromEnv() (EndPoint, error) {
-	err := godotenv.Load()
+func EndPointFromEnv(files ...string) (EndPoint, error) {
+	err := LoadEnv(files...)
+	if err != nil {
+		return EndPoint{}, err
+	}
+	str, err := LookupEnv(ENV_ENDPOINT...)
 	if err != nil {
 		return EndPoint{}, err
 	}
-	str := os.Getenv("ALIYUN_ENDPOINT")
 
 	return NewEndPoint(str)
 }
@@ -151,6 +153,15 @@ func NewEndPoint(value string) (EndPoint, error) {
 	return EndPoint{value, is_internal, ""}, nil
 }
 
+// NewEndPointFromLocation 根据 oss 返回的地域（例如 oss-cn-hangzhou）创建 endpoint
+func NewEndPointFromLocation(location string, is_internal bool) (EndPoint, error) {
+	value := strings.TrimPrefix(location, "oss-")
+	if is_internal {
+		value += "-internal"
+	}
+	return NewEndPoint(value)
+}
+
 func (e EndPoint) SetInternal(is_internal bool) {
 	e.is_internal = is_internal
 }
@@ -159,6 +170,11 @@ func (e EndPoint) IsInternal() bool {
 	return e.is_internal
 }
 
+// HasOriginalDomain 是否设置了自定义的 endpoint 域名
+func (e EndPoint) HasOriginalDomain() bool {
+	return len(e.original) > 0
+}
+
 func (e *EndPoint) SetOriginalDomain(domain string) error {
 	u, err := url.Parse(domain)
 	if err != nil {
@@ -208,3 +224,61 @@ type InvalidEndPoint struct{}
 func (e *InvalidEndPoint) Error() string {
 	return "invalid endpoint"
 }
+
+// StorageClass 文件的存储类型
+type StorageClass string
+
+const (
+	STORAGE_CLASS_STANDARD          StorageClass = "Standard"
+	STORAGE_CLASS_IA                StorageClass = "IA"
+	STORAGE_CLASS_ARCHIVE           StorageClass = "Archive"
+	STORAGE_CLASS_COLD_ARCHIVE      StorageClass = "ColdArchive"
+	STORAGE_CLASS_DEEP_COLD_ARCHIVE StorageClass = "DeepColdArchive"
+)
+
+// ACL 文件或 bucket 的访问权限，ACL_DEFAULT 表示文件继承 bucket 的权限
+type ACL string
+
+const (
+	ACL_DEFAULT           ACL = "default"
+	ACL_PRIVATE           ACL = "private"
+	ACL_PUBLIC_READ       ACL = "public-read"
+	ACL_PUBLIC_READ_WRITE ACL = "public-read-write"
+)
+
+// Directive 复制文件时元数据、标签的处理方式
+type Directive string
+
+const (
+	// 复制源文件的元数据（或标签）
+	DIRECTIVE_COPY Directive = "COPY"
+	// 使用请求中指定的元数据（或标签）
+	DIRECTIVE_REPLACE Directive = "REPLACE"
+)
+
+// RestoreTier 冷归档、深度冷归档文件的解冻优先级
+type RestoreTier string
+
+const (
+	RESTORE_TIER_EXPEDITED RestoreTier = "Expedited"
+	RESTORE_TIER_STANDARD  RestoreTier = "Standard"
+	RESTORE_TIER_BULK      RestoreTier = "Bulk"
+)
+
+// VersioningStatus bucket 的版本控制状态，从未开启过时为空
+type VersioningStatus string
+
+const (
+	VERSIONING_ENABLED   VersioningStatus = "Enabled"
+	VERSIONING_SUSPENDED VersioningStatus = "Suspended"
+)
+
+// DataRedundancyType bucket 的数据容灾类型
+type DataRedundancyType string
+
+const (
+	// 本地冗余存储
+	DATA_REDUNDANCY_LRS DataRedundancyType = "LRS"
+	// 同城冗余存储
+	DATA_REDUNDANCY_ZRS DataRedundancyType = "ZRS"
+)
@@ -11,3 +11,20 @@ func TestSecretEncryption(t *testing.T) {
 		t.Error("secret encryption error")
 	}
 }
+
+func TestLookupEnv(t *testing.T) {
+	t.Setenv("ALIYUN_KEY_ID", "")
+	t.Setenv("OSS_ACCESS_KEY_ID", "foo")
+	value, err := LookupEnv(ENV_KEY_ID...)
+	if err != nil || value != "foo" {
+		t.Error("lookup env by alternate name failed")
+	}
+
+	t.Setenv("OSS_ACCESS_KEY_ID", "")
+	t.Setenv("ALIBABA_CLOUD_ACCESS_KEY_ID", "")
+	_, err = LookupEnv(ENV_KEY_ID...)
+	env_err, ok := err.(*EnvEmtpyError)
+	if !ok || env_err.Names[0] != "ALIYUN_KEY_ID" {
+		t.Error("lookup env should report missing variable")
+	}
+}